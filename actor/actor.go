@@ -3,6 +3,7 @@ package actor
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -24,6 +25,10 @@ type ActorRef interface {
 	IsRunning() bool
 }
 
+type Drainer interface {
+	Drain(ctx context.Context) error
+}
+
 type DefaultActor struct {
 	id          string
 	mailbox     chan interface{}
@@ -36,6 +41,9 @@ type DefaultActor struct {
 	lastError   error
 	stateData   map[string]interface{}
 	stateDataMu sync.RWMutex
+	pending     atomic.Int64
+	received    atomic.Uint64
+	processed   atomic.Uint64
 }
 
 func NewActor(id string, receiver func(context.Context, interface{}) error, bufferSize int) *DefaultActor {
@@ -63,6 +71,8 @@ func (a *DefaultActor) processMessages() {
 		select {
 		case msg := <-a.mailbox:
 			err := a.receiver(a.ctx, msg)
+			a.pending.Add(-1)
+			a.processed.Add(1)
 			if err != nil {
 				a.setLastError(err)
 
@@ -81,6 +91,17 @@ func (a *DefaultActor) Receive(ctx context.Context, message interface{}) error {
 	}
 	a.mu.RUnlock()
 
+	a.pending.Add(1)
+	a.received.Add(1)
+	err := a.enqueue(ctx, message)
+	if err != nil {
+		a.pending.Add(-1)
+		a.processed.Add(1)
+	}
+	return err
+}
+
+func (a *DefaultActor) enqueue(ctx context.Context, message interface{}) error {
 	select {
 	case a.mailbox <- message:
 		return nil
@@ -107,6 +128,30 @@ func (a *DefaultActor) Receive(ctx context.Context, message interface{}) error {
 	}
 }
 
+func (a *DefaultActor) Drain(ctx context.Context) error {
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+
+	target := a.received.Load()
+	for {
+		if a.processed.Load() >= target {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-a.ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (a *DefaultActor) PendingMessages() int {
+	return int(a.pending.Load())
+}
+
 func (a *DefaultActor) Stop() error {
 	a.mu.Lock()
 	defer a.mu.Unlock()
@@ -118,7 +163,6 @@ func (a *DefaultActor) Stop() error {
 	a.stopped = true
 	a.cancel()
 	a.wg.Wait()
-	close(a.mailbox)
 	return nil
 }
 
@@ -132,6 +176,10 @@ func (a *DefaultActor) IsRunning() bool {
 	return !a.stopped
 }
 
+func (a *DefaultActor) Done() <-chan struct{} {
+	return a.ctx.Done()
+}
+
 func (a *DefaultActor) SetState(key string, value interface{}) {
 	a.stateDataMu.Lock()
 	defer a.stateDataMu.Unlock()
@@ -176,3 +224,98 @@ func (r *ActorRefImpl) ID() string {
 func (r *ActorRefImpl) IsRunning() bool {
 	return r.actor.IsRunning()
 }
+
+const restartGracePeriod = 500 * time.Millisecond
+
+type IncarnationRef interface {
+	ActorRef
+
+	Incarnation() uint64
+}
+
+type StableRef struct {
+	id          string
+	actor       Actor
+	incarnation uint64
+	rebound     chan struct{}
+	terminated  bool
+	mu          sync.RWMutex
+}
+
+func NewStableRef(actor Actor) *StableRef {
+	return &StableRef{
+		id:          actor.ID(),
+		actor:       actor,
+		incarnation: 1,
+		rebound:     make(chan struct{}),
+	}
+}
+
+func (r *StableRef) Send(ctx context.Context, message interface{}) error {
+	r.mu.RLock()
+	current, rebound, terminated := r.actor, r.rebound, r.terminated
+	r.mu.RUnlock()
+
+	err := current.Receive(ctx, message)
+	if err != ErrActorStopped || terminated {
+		return err
+	}
+
+	timer := time.NewTimer(restartGracePeriod)
+	defer timer.Stop()
+
+	select {
+	case <-rebound:
+		return r.Send(ctx, message)
+	case <-timer.C:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (r *StableRef) ID() string {
+	return r.id
+}
+
+func (r *StableRef) IsRunning() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return !r.terminated && r.actor.IsRunning()
+}
+
+func (r *StableRef) Incarnation() uint64 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.incarnation
+}
+
+func (r *StableRef) Actor() Actor {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.actor
+}
+
+func (r *StableRef) Rebind(actor Actor) uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.actor = actor
+	r.incarnation++
+	r.terminated = false
+	close(r.rebound)
+	r.rebound = make(chan struct{})
+	return r.incarnation
+}
+
+func (r *StableRef) Terminate() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.terminated {
+		return
+	}
+	r.terminated = true
+	close(r.rebound)
+	r.rebound = make(chan struct{})
+}
@@ -0,0 +1,134 @@
+package actor
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestStableRefRebind(t *testing.T) {
+	var first, second atomic.Int64
+	old := NewActor("worker", func(context.Context, interface{}) error { first.Add(1); return nil }, 10)
+	ref := NewStableRef(old)
+
+	if ref.Incarnation() != 1 {
+		t.Fatalf("Expected incarnation 1, got %d", ref.Incarnation())
+	}
+	if err := ref.Send(context.Background(), "hello"); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+
+	_ = old.Drain(context.Background())
+	_ = old.Stop()
+	replacement := NewActor("worker", func(context.Context, interface{}) error { second.Add(1); return nil }, 10)
+	defer replacement.Stop()
+
+	if incarnation := ref.Rebind(replacement); incarnation != 2 {
+		t.Errorf("Expected incarnation 2 after rebind, got %d", incarnation)
+	}
+	if err := ref.Send(context.Background(), "again"); err != nil {
+		t.Fatalf("Send after rebind failed: %v", err)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if first.Load() != 1 || second.Load() != 1 {
+		t.Errorf("Expected one message per incarnation, got %d and %d", first.Load(), second.Load())
+	}
+	if ref.Actor() != replacement {
+		t.Error("Expected ref to point at the new incarnation")
+	}
+}
+
+func TestStableRefSendWaitsForRestart(t *testing.T) {
+	var received atomic.Int64
+	old := NewActor("worker", func(context.Context, interface{}) error { return nil }, 10)
+	ref := NewStableRef(old)
+	_ = old.Stop()
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		ref.Rebind(NewActor("worker", func(context.Context, interface{}) error { received.Add(1); return nil }, 10))
+	}()
+
+	if err := ref.Send(context.Background(), "during restart"); err != nil {
+		t.Fatalf("Expected send during restart to reach the new incarnation, got %v", err)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if received.Load() != 1 {
+		t.Errorf("Expected the new incarnation to receive the message, got %d", received.Load())
+	}
+	_ = ref.Actor().Stop()
+}
+
+func TestStableRefTerminate(t *testing.T) {
+	old := NewActor("worker", func(context.Context, interface{}) error { return nil }, 10)
+	ref := NewStableRef(old)
+	_ = old.Stop()
+	ref.Terminate()
+
+	start := time.Now()
+	if err := ref.Send(context.Background(), "late"); !errors.Is(err, ErrActorStopped) {
+		t.Errorf("Expected ErrActorStopped, got %v", err)
+	}
+	if time.Since(start) >= restartGracePeriod {
+		t.Error("Expected a terminated ref not to wait for a restart")
+	}
+	if ref.IsRunning() {
+		t.Error("Expected a terminated ref not to be running")
+	}
+}
+
+func TestParseAddress(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Address
+		err   bool
+	}{
+		{"worker@n1", Address{Node: "n1", ID: "worker"}, false},
+		{"name:cache@n2", Address{Node: "n2", Name: "cache"}, false},
+		{"a@b@n3", Address{Node: "n3", ID: "a@b"}, false},
+		{"worker", Address{}, true},
+		{"@n1", Address{}, true},
+		{"worker@", Address{}, true},
+		{"name:@n1", Address{}, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseAddress(tt.input)
+		if (err != nil) != tt.err || got != tt.want {
+			t.Errorf("ParseAddress(%q) = %+v, %v", tt.input, got, err)
+		}
+		if err == nil && got.String() != tt.input {
+			t.Errorf("Expected %+v to format as %q, got %q", got, tt.input, got.String())
+		}
+	}
+}
+
+func TestDrainCountsRejectedMessages(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{}, 1)
+	a := NewActor("worker", func(context.Context, interface{}) error {
+		started <- struct{}{}
+		<-release
+		return nil
+	}, 1)
+	defer a.Stop()
+
+	ctx := context.Background()
+	_ = a.Receive(ctx, "first")
+	<-started
+	_ = a.Receive(ctx, "second")
+	if err := a.Receive(ctx, "third"); !errors.Is(err, ErrMailboxFull) {
+		t.Fatalf("Expected ErrMailboxFull, got %v", err)
+	}
+	close(release)
+
+	drainCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	if err := a.Drain(drainCtx); err != nil {
+		t.Errorf("Expected Drain to finish once the accepted messages are processed, got %v", err)
+	}
+}
@@ -0,0 +1,42 @@
+package actor
+
+import (
+	"strings"
+)
+
+const namePrefix = "name:"
+
+type Address struct {
+	Node string
+	ID   string
+	Name string
+}
+
+func ParseAddress(s string) (Address, error) {
+	at := strings.LastIndex(s, "@")
+	if at <= 0 || at == len(s)-1 {
+		return Address{}, ErrInvalidAddress
+	}
+
+	target, node := s[:at], s[at+1:]
+	if strings.HasPrefix(target, namePrefix) {
+		name := strings.TrimPrefix(target, namePrefix)
+		if name == "" {
+			return Address{}, ErrInvalidAddress
+		}
+		return Address{Node: node, Name: name}, nil
+	}
+
+	return Address{Node: node, ID: target}, nil
+}
+
+func (a Address) String() string {
+	if a.Name != "" {
+		return namePrefix + a.Name + "@" + a.Node
+	}
+	return a.ID + "@" + a.Node
+}
+
+func (a Address) IsNamed() bool {
+	return a.Name != ""
+}
@@ -10,4 +10,8 @@ var (
 	ErrInvalidActorID = errors.New("invalid actor ID")
 
 	ErrMailboxFull = errors.New("actor mailbox is full")
+
+	ErrInvalidAddress = errors.New("invalid actor address")
+
+	ErrNoConnection = errors.New("noconnection")
 )
@@ -19,6 +19,15 @@ type MonitorMessage struct {
 	MonitorID   string
 	Reason      error
 	Timestamp   int64
+	Incarnation uint64
+}
+
+type NodeMonitorMessage struct {
+	Node      string
+	MonitorID string
+	Up        bool
+	Reason    error
+	Timestamp int64
 }
 
 type monitorLink struct {
@@ -31,13 +40,16 @@ type MonitorRegistry struct {
 	monitors map[string]map[string]MonitorType
 
 	monitoring map[string]map[string]MonitorType
-	mu         sync.RWMutex
+
+	nodeMonitors map[string]map[string]struct{}
+	mu           sync.RWMutex
 }
 
 func NewMonitorRegistry() *MonitorRegistry {
 	return &MonitorRegistry{
-		monitors:   make(map[string]map[string]MonitorType),
-		monitoring: make(map[string]map[string]MonitorType),
+		monitors:     make(map[string]map[string]MonitorType),
+		monitoring:   make(map[string]map[string]MonitorType),
+		nodeMonitors: make(map[string]map[string]struct{}),
 	}
 }
 
@@ -122,6 +134,58 @@ func (r *MonitorRegistry) GetMonitored(actorID string) []string {
 	return nil
 }
 
+func (r *MonitorRegistry) MonitorNode(monitorID, node string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.nodeMonitors[node]; !exists {
+		r.nodeMonitors[node] = make(map[string]struct{})
+	}
+	r.nodeMonitors[node][monitorID] = struct{}{}
+}
+
+func (r *MonitorRegistry) DemonitorNode(monitorID, node string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if m, exists := r.nodeMonitors[node]; exists {
+		delete(m, monitorID)
+		if len(m) == 0 {
+			delete(r.nodeMonitors, node)
+		}
+	}
+}
+
+func (r *MonitorRegistry) GetNodeMonitors(node string) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ids := make([]string, 0, len(r.nodeMonitors[node]))
+	for id := range r.nodeMonitors[node] {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func (r *MonitorRegistry) WatchedActors() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	seen := make(map[string]struct{}, len(r.monitors)+len(r.monitoring))
+	for id := range r.monitors {
+		seen[id] = struct{}{}
+	}
+	for id := range r.monitoring {
+		seen[id] = struct{}{}
+	}
+
+	ids := make([]string, 0, len(seen))
+	for id := range seen {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
 func (r *MonitorRegistry) NotifyMonitors(ctx context.Context, actorID string, reason error, actorSystem ActorSystem) {
 	r.mu.RLock()
 	monitors := r.GetMonitors(actorID)
@@ -131,14 +195,21 @@ func (r *MonitorRegistry) NotifyMonitors(ctx context.Context, actorID string, re
 		return
 	}
 
-	msg := &MonitorMessage{
-		MonitoredID: actorID,
-		Reason:      reason,
-		Timestamp:   time.Now().UnixNano(),
+	var incarnation uint64
+	if monitoredRef, err := actorSystem.GetActor(actorID); err == nil {
+		if ref, ok := monitoredRef.(IncarnationRef); ok {
+			incarnation = ref.Incarnation()
+		}
 	}
 
 	for _, monitorID := range monitors {
-		msg.MonitorID = monitorID
+		msg := &MonitorMessage{
+			MonitoredID: actorID,
+			MonitorID:   monitorID,
+			Reason:      reason,
+			Timestamp:   time.Now().UnixNano(),
+			Incarnation: incarnation,
+		}
 		actorRef, err := actorSystem.GetActor(monitorID)
 		if err == nil {
 			_ = actorRef.Send(ctx, msg)
@@ -177,6 +248,13 @@ func (r *MonitorRegistry) CleanupActor(actorID string) {
 			delete(m, actorID)
 		}
 	}
+
+	for node, m := range r.nodeMonitors {
+		delete(m, actorID)
+		if len(m) == 0 {
+			delete(r.nodeMonitors, node)
+		}
+	}
 }
 
 var now = func() time.Time {
@@ -2,14 +2,41 @@ package bridge
 
 import (
 	"context"
+	"fmt"
+	"time"
 
+	"github.com/kleeedolinux/gorilix/actor"
 	"github.com/kleeedolinux/gorilix/cluster"
+	"github.com/kleeedolinux/gorilix/cluster/ddata"
+	"github.com/kleeedolinux/gorilix/cluster/election"
+	"github.com/kleeedolinux/gorilix/cluster/global"
+	"github.com/kleeedolinux/gorilix/cluster/pg"
+	"github.com/kleeedolinux/gorilix/cluster/sharding"
+	"github.com/kleeedolinux/gorilix/cluster/singleton"
+	"github.com/kleeedolinux/gorilix/messaging"
 	"github.com/kleeedolinux/gorilix/system"
 )
 
+const (
+	kindSpawn = "spawn"
+
+	splitBrainShutdownTimeout = 10 * time.Second
+)
+
+
+type spawnReply struct {
+	ID string `json:"id"`
+}
+
 
 type ClusterAdapter struct {
 	*cluster.Cluster
+	global     *global.Registry
+	groups     *pg.Groups
+	singletons *singleton.Manager
+	sharding   *sharding.Sharding
+	election   *election.Election
+	replicator *ddata.Replicator
 }
 
 
@@ -30,6 +57,71 @@ func (a *ClusterAdapter) Members() []system.Node {
 }
 
 
+func (a *ClusterAdapter) RemoteRef(address actor.Address) actor.ActorRef {
+	return a.Cluster.RemoteRef(address)
+}
+
+
+func (a *ClusterAdapter) Spawn(ctx context.Context, node string, request system.SpawnRequest) (actor.ActorRef, error) {
+	reply, err := a.Cluster.Request(ctx, node, kindSpawn, request)
+	if err != nil {
+		return nil, err
+	}
+
+	var result spawnReply
+	if err := cluster.DecodePayload(reply, &result); err != nil {
+		return nil, fmt.Errorf("invalid spawn reply from node %s: %w", node, err)
+	}
+
+	return a.Cluster.RemoteRef(actor.Address{Node: node, ID: result.ID}), nil
+}
+
+
+func (a *ClusterAdapter) Global() *global.Registry {
+	return a.global
+}
+
+
+func (a *ClusterAdapter) Groups() *pg.Groups {
+	return a.groups
+}
+
+
+func (a *ClusterAdapter) Singletons() *singleton.Manager {
+	return a.singletons
+}
+
+
+func (a *ClusterAdapter) Sharding() *sharding.Sharding {
+	return a.sharding
+}
+
+
+func (a *ClusterAdapter) Election() *election.Election {
+	return a.election
+}
+
+
+func (a *ClusterAdapter) Replicator() *ddata.Replicator {
+	return a.replicator
+}
+
+
+func (a *ClusterAdapter) RegisterGlobal(ctx context.Context, name string, ref actor.ActorRef) error {
+	return a.global.Register(ctx, name, ref)
+}
+
+
+func (a *ClusterAdapter) UnregisterGlobal(name string) error {
+	return a.global.Unregister(name)
+}
+
+
+func (a *ClusterAdapter) WhereIsGlobal(name string) (actor.ActorRef, bool) {
+	return a.global.WhereIs(name)
+}
+
+
 type NodeAdapter struct {
 	*cluster.Node
 }
@@ -55,7 +147,9 @@ func (n *NodeAdapter) GetStatus() int {
 }
 
 
-type ClusterProvider struct{}
+type ClusterProvider struct {
+	ConflictResolver global.ConflictResolver
+}
 
 
 func NewClusterProvider() *ClusterProvider {
@@ -69,6 +163,11 @@ func (p *ClusterProvider) NewCluster(config *system.ClusterConfig, sys interface
 		return nil, cluster.ErrInvalidSystemReference
 	}
 
+	codec, err := cluster.CodecByName(config.Codec)
+	if err != nil {
+		return nil, err
+	}
+
 	
 	clusterConfig := &cluster.ClusterConfig{
 		NodeName:     config.NodeName,
@@ -78,11 +177,57 @@ func (p *ClusterProvider) NewCluster(config *system.ClusterConfig, sys interface
 		GossipNodes:  3,
 		PushInterval: cluster.DefaultConfig().PushInterval,
 		PullInterval: cluster.DefaultConfig().PullInterval,
+		Codec:        codec,
+		Roles:        config.Roles,
+		DownAfter:    config.DownAfter,
+
+		SecretKeys:      config.SecretKeys,
+		TLSConfig:       config.TLSConfig,
+		AdmissionSecret: config.AdmissionSecret,
+	}
+
+	if sbr := config.SplitBrain; sbr != nil {
+		clusterConfig.SplitBrain = &cluster.SplitBrainConfig{
+			Strategy:    cluster.SplitBrainStrategy(sbr.Strategy),
+			StableAfter: sbr.StableAfter,
+			Role:        sbr.Role,
+			Referee:     sbr.Referee,
+			QuorumSize:  sbr.QuorumSize,
+			DownIfAlone: sbr.DownIfAlone,
+		}
+		if !sbr.LeaveOnly {
+			clusterConfig.SplitBrain.OnDown = func() {
+				ctx, cancel := context.WithTimeout(context.Background(), splitBrainShutdownTimeout)
+				defer cancel()
+				_ = actorSystem.Shutdown(ctx)
+			}
+		}
 	}
 
 	
 	clusterInstance := cluster.NewCluster(clusterConfig, &systemAdapter{actorSystem})
-	return &ClusterAdapter{clusterInstance}, nil
+	clusterInstance.HandleRequest(kindSpawn, func(ctx context.Context, msg *messaging.Message) (interface{}, error) {
+		var request system.SpawnRequest
+		if err := cluster.DecodePayload(msg, &request); err != nil {
+			return nil, err
+		}
+
+		ref, err := actorSystem.SpawnFromFactory(request.Factory, request.Args, request.Options)
+		if err != nil {
+			return nil, err
+		}
+		return spawnReply{ID: ref.ID()}, nil
+	})
+
+	return &ClusterAdapter{
+		Cluster:    clusterInstance,
+		global:     global.NewRegistry(clusterInstance, p.ConflictResolver),
+		groups:     pg.NewGroups(clusterInstance),
+		singletons: singleton.NewManager(clusterInstance),
+		sharding:   sharding.NewSharding(clusterInstance),
+		election:   election.NewElection(clusterInstance),
+		replicator: ddata.NewReplicator(clusterInstance),
+	}, nil
 }
 
 
@@ -99,3 +244,38 @@ func (a *systemAdapter) SendMessage(ctx context.Context, actorID string, message
 func (a *systemAdapter) SendNamedMessage(ctx context.Context, name string, message interface{}) error {
 	return a.system.SendNamedMessage(ctx, name, message)
 }
+
+
+func (a *systemAdapter) GetActor(id string) (actor.ActorRef, error) {
+	return a.system.GetActor(id)
+}
+
+
+func (a *systemAdapter) Monitor(monitorID, monitoredID string, linkType actor.MonitorType) error {
+	return a.system.Monitor(monitorID, monitoredID, linkType)
+}
+
+
+func (a *systemAdapter) Demonitor(monitorID, monitoredID string) error {
+	return a.system.Demonitor(monitorID, monitoredID)
+}
+
+
+func (a *systemAdapter) NotifyFailure(ctx context.Context, actorID string, reason error) error {
+	return a.system.NotifyFailure(ctx, actorID, reason)
+}
+
+
+func (a *systemAdapter) NotifyNodeUp(ctx context.Context, node string) {
+	a.system.NotifyNodeUp(ctx, node)
+}
+
+
+func (a *systemAdapter) NotifyNodeDown(ctx context.Context, node string, reason error) {
+	a.system.NotifyNodeDown(ctx, node, reason)
+}
+
+
+func (a *systemAdapter) GetMessageBus() *messaging.MessageBus {
+	return a.system.GetMessageBus()
+}
@@ -0,0 +1,232 @@
+package bridge
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kleeedolinux/gorilix/actor"
+	"github.com/kleeedolinux/gorilix/genserver"
+	"github.com/kleeedolinux/gorilix/supervisor"
+	"github.com/kleeedolinux/gorilix/system"
+)
+
+func newClusteredSystem(t *testing.T, node string) (*system.ActorSystem, *ClusterAdapter) {
+	t.Helper()
+
+	sys := system.NewActorSystem(node)
+	sys.SetClusterProvider(NewClusterProvider())
+	if err := sys.EnableClustering(&system.ClusterConfig{NodeName: node, BindAddr: "127.0.0.1"}); err != nil {
+		t.Fatalf("Failed to enable clustering: %v", err)
+	}
+	t.Cleanup(func() { _ = sys.Stop() })
+
+	cluster, err := sys.GetCluster()
+	if err != nil {
+		t.Fatalf("Failed to get cluster: %v", err)
+	}
+	return sys, cluster.(*ClusterAdapter)
+}
+
+func spawnCollector(t *testing.T, sys *system.ActorSystem, id string) <-chan interface{} {
+	t.Helper()
+
+	received := make(chan interface{}, 10)
+	_, err := sys.SpawnActor(id, func(ctx context.Context, msg interface{}) error {
+		received <- msg
+		return nil
+	}, 10)
+	if err != nil {
+		t.Fatalf("Failed to spawn %s: %v", id, err)
+	}
+	return received
+}
+
+func TestRemoteRefSendsThroughCluster(t *testing.T) {
+	sys, adapter := newClusteredSystem(t, "n1")
+	received := spawnCollector(t, sys, "worker")
+
+	ref := adapter.RemoteRef(actor.Address{Node: "n1", ID: "worker"})
+	if !ref.IsRunning() {
+		t.Fatal("Expected remote ref on a running node to be running")
+	}
+	if err := ref.Send(context.Background(), "hello"); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+
+	select {
+	case msg := <-received:
+		if payload, ok := msg.([]byte); !ok || string(payload) != "hello" {
+			t.Errorf("Unexpected message %#v", msg)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected message to arrive through the cluster")
+	}
+}
+
+func TestRemoteRefReportsMissingActor(t *testing.T) {
+	_, adapter := newClusteredSystem(t, "n1")
+
+	if ref := adapter.RemoteRef(actor.Address{Node: "n9", ID: "worker"}); ref.IsRunning() {
+		t.Error("Expected remote ref on an unknown node not to be running")
+	}
+
+	ref := adapter.RemoteRef(actor.Address{Node: "n1", ID: "missing"})
+	if err := ref.Send(context.Background(), "hello"); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for ref.IsRunning() {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected remote ref to stop running after the actor was not found")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestGetActorResolvesRemoteAddress(t *testing.T) {
+	sys, _ := newClusteredSystem(t, "n1")
+	spawnCollector(t, sys, "worker")
+
+	local, err := sys.GetActor("worker@n1")
+	if err != nil || local.ID() != "worker" {
+		t.Fatalf("Expected own-node address to resolve locally, got %v, %v", local, err)
+	}
+
+	remote, err := sys.GetActor("name:cache@n2")
+	if err != nil {
+		t.Fatalf("GetActor failed: %v", err)
+	}
+	if remote.ID() != "name:cache@n2" {
+		t.Errorf("Expected remote ref for name:cache@n2, got %s", remote.ID())
+	}
+}
+
+type workerConfig struct {
+	Queue string `json:"queue"`
+	Size  int    `json:"size"`
+}
+
+func TestSpawnDecodesArgsIntoRegisteredType(t *testing.T) {
+	sys, adapter := newClusteredSystem(t, "n1")
+
+	args := make(chan interface{}, 2)
+	factory := func(id string, a interface{}) (actor.Actor, error) {
+		args <- a
+		return actor.NewActor(id, func(ctx context.Context, msg interface{}) error { return nil }, 10), nil
+	}
+	if err := sys.RegisterActorFactory("typed", factory); err != nil {
+		t.Fatalf("Failed to register factory: %v", err)
+	}
+	if err := sys.RegisterFactoryArgs("typed", workerConfig{}); err != nil {
+		t.Fatalf("Failed to register factory args: %v", err)
+	}
+	if err := sys.RegisterActorFactory("untyped", factory); err != nil {
+		t.Fatalf("Failed to register factory: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	config := workerConfig{Queue: "jobs", Size: 3}
+	ref, err := adapter.Spawn(ctx, "n1", system.SpawnRequest{Factory: "typed", Args: config})
+	if err != nil {
+		t.Fatalf("Spawn failed: %v", err)
+	}
+	if got := <-args; got != config {
+		t.Errorf("Expected factory to receive %+v, got %#v", config, got)
+	}
+	if ref.ID() == "" || !ref.IsRunning() {
+		t.Errorf("Expected a running remote ref, got %q", ref.ID())
+	}
+
+	if _, err := adapter.Spawn(ctx, "n1", system.SpawnRequest{Factory: "untyped", Args: config}); err != nil {
+		t.Fatalf("Spawn failed: %v", err)
+	}
+	if got, ok := (<-args).(map[string]interface{}); !ok || got["queue"] != "jobs" || got["size"] != float64(3) {
+		t.Errorf("Expected untyped factory to receive decoded JSON, got %#v", got)
+	}
+
+	if _, err := adapter.Spawn(ctx, "n1", system.SpawnRequest{Factory: "typed", Args: "not a config"}); err == nil {
+		t.Error("Expected spawn with undecodable args to fail")
+	}
+}
+
+func TestSpawnGenServerUnderNamedSupervisor(t *testing.T) {
+	sys, adapter := newClusteredSystem(t, "n1")
+
+	counters, err := sys.SpawnSupervisor("counters", supervisor.OneForOne, 3, 5)
+	if err != nil {
+		t.Fatalf("Failed to spawn supervisor: %v", err)
+	}
+	err = sys.RegisterGenServerFactory("counter", func(args interface{}) (genserver.Options, error) {
+		return genserver.Options{
+			InitFunc: func(ctx context.Context, args interface{}) (interface{}, error) {
+				return args, nil
+			},
+			InitArgs: args,
+		}, nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to register factory: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	ref, err := adapter.Spawn(ctx, "n1", system.SpawnRequest{
+		Factory: "counter",
+		Args:    1,
+		Options: system.SpawnOptions{ID: "counter-1", Supervisor: "counters"},
+	})
+	if err != nil {
+		t.Fatalf("Spawn failed: %v", err)
+	}
+	if ref.ID() != "counter-1@n1" {
+		t.Errorf("Expected remote ref counter-1@n1, got %s", ref.ID())
+	}
+
+	if _, err := counters.GetChild("counter-1"); err != nil {
+		t.Errorf("Expected counter-1 under the counters supervisor: %v", err)
+	}
+
+	_, err = adapter.Spawn(ctx, "n1", system.SpawnRequest{Factory: "missing"})
+	if err == nil {
+		t.Error("Expected spawn from a missing factory to fail")
+	}
+}
+
+func TestMonitorThroughCluster(t *testing.T) {
+	sys1, _ := newClusteredSystem(t, "n1")
+	sys2, adapter2 := newClusteredSystem(t, "n2")
+	received := spawnCollector(t, sys1, "watcher")
+	spawnCollector(t, sys2, "worker")
+
+	self := adapter2.Self()
+	if _, err := sys1.JoinCluster([]string{fmt.Sprintf("%s:%d", self.GetAddress(), self.GetPort())}); err != nil {
+		t.Fatalf("Join failed: %v", err)
+	}
+
+	if err := sys1.Monitor("watcher", "missing@n2", actor.OneWay); err == nil {
+		t.Error("Expected monitoring a missing remote actor to fail")
+	}
+	if err := sys1.Monitor("watcher", "worker@n2", actor.OneWay); err != nil {
+		t.Fatalf("Monitor failed: %v", err)
+	}
+
+	if err := sys2.NotifyFailure(context.Background(), "worker", actor.ErrActorStopped); err != nil {
+		t.Fatalf("NotifyFailure failed: %v", err)
+	}
+
+	select {
+	case msg := <-received:
+		down, ok := msg.(*actor.MonitorMessage)
+		if !ok || down.MonitoredID != "worker@n2" || down.Reason != actor.ErrActorStopped {
+			t.Errorf("Unexpected DOWN message %#v", msg)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected a DOWN message for worker@n2")
+	}
+}
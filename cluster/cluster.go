@@ -2,13 +2,19 @@ package cluster
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net"
 	"os"
+	"sort"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/hashicorp/memberlist"
+	"github.com/kleeedolinux/gorilix/actor"
+	"github.com/kleeedolinux/gorilix/messaging"
 )
 
 
@@ -16,6 +22,13 @@ import (
 type SystemReference interface {
 	SendMessage(ctx context.Context, actorID string, message interface{}) error
 	SendNamedMessage(ctx context.Context, name string, message interface{}) error
+	GetActor(id string) (actor.ActorRef, error)
+	Monitor(monitorID, monitoredID string, linkType actor.MonitorType) error
+	Demonitor(monitorID, monitoredID string) error
+	NotifyFailure(ctx context.Context, actorID string, reason error) error
+	NotifyNodeUp(ctx context.Context, node string)
+	NotifyNodeDown(ctx context.Context, node string, reason error)
+	GetMessageBus() *messaging.MessageBus
 }
 
 type ClusterConfig struct {
@@ -27,17 +40,62 @@ type ClusterConfig struct {
 	GossipPort   int
 	PushInterval time.Duration
 	PullInterval time.Duration
+	Codec        Codec
+	Roles        []string
+	DownAfter    time.Duration
+
+	SecretKeys      [][]byte
+	TLSConfig       *tls.Config
+	AdmissionSecret string
+
+	SplitBrain *SplitBrainConfig
 }
 
 type Cluster struct {
-	config     *ClusterConfig
-	memberlist *memberlist.Memberlist
-	events     chan memberlist.NodeEvent
-	delegates  *clusterDelegate
-	system     SystemReference
-	nodesMutex sync.RWMutex
-	nodes      map[string]*Node
-	running    bool
+	config           *ClusterConfig
+	memberlist       *memberlist.Memberlist
+	keyring          *memberlist.Keyring
+	events           chan memberlist.NodeEvent
+	delegates        *clusterDelegate
+	system           SystemReference
+	nodesMutex       sync.RWMutex
+	nodes            map[string]*Node
+	handlers         map[string]KindHandler
+	handlersMu       sync.RWMutex
+	deliveryFailures chan DeliveryFailure
+	deadLetters      chan DeadLetter
+	remoteRefs       map[string]*RemoteRef
+	remoteRefsMu     sync.RWMutex
+	pending          map[string]chan *messaging.Message
+	pendingMu        sync.Mutex
+	outboxes         map[string]*outbox
+	outboxesMu       sync.Mutex
+	inboxes          map[string]*inbox
+	inboxesMu        sync.Mutex
+	unreachable      map[string]time.Time
+	membersChangedAt atomic.Int64
+	splitBrainDowned atomic.Bool
+	listeners        []NodeListener
+	leaveHooks       []func()
+	startHooks       []func()
+	listenersMu      sync.RWMutex
+	memberSubs       []actor.ActorRef
+	memberSubsMu     sync.RWMutex
+	notifications    []func()
+	notifyMu         sync.Mutex
+	notifyWake       chan struct{}
+	topics           *topicRouter
+	bus              *messaging.MessageBus
+	schemas          *schemaBook
+	codec            Codec
+	stopCh           chan struct{}
+	running          atomic.Bool
+	inflight         chan struct{}
+	lanes            map[string]chan inbound
+	lanesMu          sync.Mutex
+	replays          *replayGuard
+	admitted         map[string]int64
+	admittedMu       sync.Mutex
 }
 
 type Node struct {
@@ -55,13 +113,18 @@ const (
 	NodeAlive NodeStatus = iota
 	NodeSuspect
 	NodeDead
+	NodeJoining
+	NodeLeaving
+	NodeRemoved
 )
 
 
 type clusterDelegate struct {
 	broadcasts *memberlist.TransmitLimitedQueue
 	msgCh      chan []byte
+	dropped    func([]byte)
 	metadata   map[string]string
+	states     map[string]StateDelegate
 	mtx        sync.RWMutex
 }
 
@@ -69,6 +132,7 @@ func newClusterDelegate() *clusterDelegate {
 	d := &clusterDelegate{
 		msgCh:    make(chan []byte, 1024),
 		metadata: make(map[string]string),
+		states:   make(map[string]StateDelegate),
 	}
 	d.broadcasts = &memberlist.TransmitLimitedQueue{
 		NumNodes:       func() int { return 3 },
@@ -95,16 +159,39 @@ func (d *clusterDelegate) NodeMeta(limit int) []byte {
 	return meta
 }
 
+func decodeNodeMeta(data []byte) map[string]string {
+	meta := make(map[string]string)
+	for len(data) > 0 {
+		keyLen := int(data[0])
+		if len(data) < 1+keyLen+1 {
+			break
+		}
+		key := string(data[1 : 1+keyLen])
+		data = data[1+keyLen:]
+
+		valueLen := int(data[0])
+		if len(data) < 1+valueLen {
+			break
+		}
+		meta[key] = string(data[1 : 1+valueLen])
+		data = data[1+valueLen:]
+	}
+	return meta
+}
+
 
 func (d *clusterDelegate) NotifyMsg(msg []byte) {
 	if len(msg) == 0 {
 		return
 	}
 
+	frame := append([]byte(nil), msg...)
 	select {
-	case d.msgCh <- msg:
+	case d.msgCh <- frame:
 	default:
-		
+		if d.dropped != nil {
+			go d.dropped(frame)
+		}
 	}
 }
 
@@ -114,15 +201,6 @@ func (d *clusterDelegate) GetBroadcasts(overhead, limit int) [][]byte {
 }
 
 
-func (d *clusterDelegate) LocalState(join bool) []byte {
-	return []byte{} 
-}
-
-
-func (d *clusterDelegate) MergeRemoteState(buf []byte, join bool) {
-	
-}
-
 func DefaultConfig() *ClusterConfig {
 	return &ClusterConfig{
 		NodeName:     "",
@@ -132,6 +210,7 @@ func DefaultConfig() *ClusterConfig {
 		GossipPort:   7946,
 		PushInterval: 1 * time.Second,
 		PullInterval: 3 * time.Second,
+		DownAfter:    defaultDownAfter,
 	}
 }
 
@@ -149,38 +228,145 @@ func NewCluster(config *ClusterConfig, system SystemReference) *Cluster {
 		}
 	}
 
-	return &Cluster{
-		config:    config,
-		events:    make(chan memberlist.NodeEvent, 100),
-		delegates: newClusterDelegate(),
-		system:    system,
-		nodes:     make(map[string]*Node),
-		running:   false,
+	c := &Cluster{
+		config:           config,
+		events:           make(chan memberlist.NodeEvent, 100),
+		delegates:        newClusterDelegate(),
+		system:           system,
+		nodes:            make(map[string]*Node),
+		unreachable:      make(map[string]time.Time),
+		handlers:         make(map[string]KindHandler),
+		deliveryFailures: make(chan DeliveryFailure, 100),
+		deadLetters:      make(chan DeadLetter, 100),
+		remoteRefs:       make(map[string]*RemoteRef),
+		pending:          make(map[string]chan *messaging.Message),
+		outboxes:         make(map[string]*outbox),
+		inboxes:          make(map[string]*inbox),
+		notifyWake:       make(chan struct{}, 1),
+		stopCh:           make(chan struct{}),
+		codec:            config.Codec,
+		inflight:         make(chan struct{}, maxInflightFrames),
+		lanes:            make(map[string]chan inbound),
+		replays:          newReplayGuard(),
+		admitted:         make(map[string]int64),
 	}
+	c.delegates.dropped = c.dropFrame
+	if c.codec == nil {
+		c.codec = JSONCodec{}
+	}
+	if config.DownAfter <= 0 {
+		config.DownAfter = defaultDownAfter
+	}
+	c.delegates.metadata[MetaCodecs] = supportedCodecs()
+	c.delegates.metadata[MetaIncarnation] = newIncarnation()
+	c.membersChangedAt.Store(time.Now().UnixNano())
+	if len(config.Roles) > 0 {
+		roles := append([]string(nil), config.Roles...)
+		sort.Strings(roles)
+		c.delegates.metadata[MetaRoles] = strings.Join(roles, ",")
+	}
+
+	c.HandleKind(KindDeliveryFailure, c.handleDeliveryFailure)
+	c.HandleKind(KindReply, c.handleReply)
+	c.HandleRequest(KindMonitor, c.handleMonitor)
+	c.HandleKind(KindDemonitor, c.handleDemonitor)
+	c.HandleKind(KindDown, c.handleDown)
+	c.HandleKind(KindLeaving, c.handleLeaving)
+	c.HandleKind(KindAck, c.handleAck)
+	c.OnNodeEvent(c.dropDelivery)
+	c.schemas = newSchemaBook(c)
+
+	if system != nil {
+		if bus := system.GetMessageBus(); bus != nil {
+			c.bus = bus
+			c.topics = newTopicRouter(c, bus)
+		}
+	}
+
+	return c
 }
 
 func (c *Cluster) Start() error {
-	if c.running {
+	if c.running.Load() {
 		return fmt.Errorf("cluster already running")
 	}
 
+	if sbr := c.config.SplitBrain; sbr != nil {
+		if err := sbr.validate(); err != nil {
+			return err
+		}
+		if sbr.StableAfter <= 0 {
+			sbr.StableAfter = defaultStableAfter
+		}
+	}
+
 	conf := memberlist.DefaultLANConfig()
 	conf.Name = c.config.NodeName
 	conf.BindAddr = c.config.BindAddr
 	conf.BindPort = c.config.BindPort
-	conf.Events = &memberlist.ChannelEventDelegate{Ch: c.events}
+	conf.Events = &eventDelegate{events: c.events, stopCh: c.stopCh}
 	conf.Delegate = c.delegates
 	conf.GossipNodes = c.config.GossipNodes
 	conf.PushPullInterval = c.config.PullInterval
 	conf.GossipInterval = c.config.PushInterval
 
+	if c.config.AdmissionSecret != "" && len(c.config.SecretKeys) == 0 {
+		return ErrAdmissionWithoutEncryption
+	}
+
+	keyring, err := newKeyring(c.config.SecretKeys)
+	if err != nil {
+		return err
+	}
+	conf.Keyring = keyring
+
+	if c.config.AdmissionSecret != "" {
+		c.delegates.mtx.Lock()
+		c.delegates.metadata[MetaAdmission] = admissionToken(c.config.AdmissionSecret, c.config.NodeName, time.Now())
+		c.delegates.mtx.Unlock()
+
+		admission := &admissionDelegate{cluster: c}
+		conf.Alive = admission
+		conf.Merge = admission
+	}
+
+	if c.config.TLSConfig != nil {
+		transport, err := newTLSTransport(c.config.BindAddr, c.config.BindPort, c.config.TLSConfig)
+		if err != nil {
+			return err
+		}
+		conf.Transport = transport
+	}
+
 	list, err := memberlist.Create(conf)
 	if err != nil {
+		if transport, ok := conf.Transport.(*tlsTransport); ok {
+			transport.Shutdown()
+		}
 		return fmt.Errorf("failed to create memberlist: %w", err)
 	}
 
+	c.keyring = keyring
+
 	c.memberlist = list
-	c.running = true
+
+	c.listenersMu.Lock()
+	c.running.Store(true)
+	hooks := append([]func(){}, c.startHooks...)
+	c.listenersMu.Unlock()
+
+	go c.handleEvents()
+	go c.dispatch()
+	go c.watchMembers()
+	go c.notifier()
+	go c.redeliver()
+	if c.config.AdmissionSecret != "" {
+		go c.refreshAdmission()
+	}
+
+	for _, hook := range hooks {
+		hook()
+	}
 
 	if len(c.config.Seeds) > 0 {
 		_, err = c.memberlist.Join(c.config.Seeds)
@@ -189,16 +375,16 @@ func (c *Cluster) Start() error {
 		}
 	}
 
-	go c.handleEvents()
-
 	return nil
 }
 
 func (c *Cluster) Stop() error {
-	if !c.running {
+	if !c.running.Load() {
 		return nil
 	}
 
+	c.leaving()
+
 	err := c.memberlist.Leave(time.Second * 5)
 	if err != nil {
 		return fmt.Errorf("error leaving cluster: %w", err)
@@ -209,39 +395,45 @@ func (c *Cluster) Stop() error {
 		return fmt.Errorf("error shutting down memberlist: %w", err)
 	}
 
-	c.running = false
-	close(c.events)
+	c.running.Store(false)
+	close(c.stopCh)
 	return nil
 }
 
+// eventDelegate stops delivering once the cluster is stopped, because
+// memberlist timers can still report nodes after Shutdown.
+type eventDelegate struct {
+	events chan<- memberlist.NodeEvent
+	stopCh <-chan struct{}
+}
+
+func (d *eventDelegate) NotifyJoin(n *memberlist.Node)   { d.notify(memberlist.NodeJoin, n) }
+func (d *eventDelegate) NotifyLeave(n *memberlist.Node)  { d.notify(memberlist.NodeLeave, n) }
+func (d *eventDelegate) NotifyUpdate(n *memberlist.Node) { d.notify(memberlist.NodeUpdate, n) }
+
+func (d *eventDelegate) notify(event memberlist.NodeEventType, n *memberlist.Node) {
+	node := *n
+	select {
+	case d.events <- memberlist.NodeEvent{Event: event, Node: &node}:
+	case <-d.stopCh:
+	}
+}
+
 func (c *Cluster) handleEvents() {
-	for event := range c.events {
-		c.nodesMutex.Lock()
-		switch event.Event {
-		case memberlist.NodeJoin:
-			c.nodes[event.Node.Name] = &Node{
-				Name:   event.Node.Name,
-				Addr:   event.Node.Addr,
-				Port:   event.Node.Port,
-				Meta:   map[string]string{},
-				Status: NodeAlive,
-			}
-		case memberlist.NodeLeave:
-			if node, exists := c.nodes[event.Node.Name]; exists {
-				node.Status = NodeSuspect
-			}
-		case memberlist.NodeUpdate:
-			if node, exists := c.nodes[event.Node.Name]; exists {
-				node.Addr = event.Node.Addr
-				node.Port = event.Node.Port
-			}
-		default:
-			
-			if node, exists := c.nodes[event.Node.Name]; exists {
-				node.Status = NodeSuspect
+	for {
+		select {
+		case <-c.stopCh:
+			return
+		case event := <-c.events:
+			switch event.Event {
+			case memberlist.NodeJoin:
+				c.nodeJoined(event.Node)
+			case memberlist.NodeLeave:
+				c.nodeLeft(event.Node)
+			case memberlist.NodeUpdate:
+				c.nodeUpdated(event.Node)
 			}
 		}
-		c.nodesMutex.Unlock()
 	}
 }
 
@@ -251,22 +443,25 @@ func (c *Cluster) Members() []*Node {
 
 	members := make([]*Node, 0, len(c.nodes))
 	for _, node := range c.nodes {
-		members = append(members, node)
+		member := *node
+		members = append(members, &member)
 	}
 	return members
 }
 
 func (c *Cluster) Join(seeds []string) (int, error) {
-	if !c.running {
+	if !c.running.Load() {
 		return 0, fmt.Errorf("cluster not running")
 	}
 	return c.memberlist.Join(seeds)
 }
 
 func (c *Cluster) Leave(timeout time.Duration) error {
-	if !c.running {
+	if !c.running.Load() {
 		return nil
 	}
+
+	c.leaving()
 	return c.memberlist.Leave(timeout)
 }
 
@@ -276,20 +471,29 @@ func (c *Cluster) Self() *Node {
 		Name:   self.Name,
 		Addr:   self.Addr,
 		Port:   self.Port,
+		Meta:   c.NodeMeta(),
 		Status: NodeAlive,
 	}
 }
 
+func (c *Cluster) NodeName() string {
+	return c.config.NodeName
+}
+
 func (c *Cluster) GetNode(name string) (*Node, bool) {
 	c.nodesMutex.RLock()
 	defer c.nodesMutex.RUnlock()
 
 	node, exists := c.nodes[name]
-	return node, exists
+	if !exists {
+		return nil, false
+	}
+	member := *node
+	return &member, true
 }
 
 func (c *Cluster) BroadcastMessage(msg []byte) error {
-	if !c.running {
+	if !c.running.Load() {
 		return fmt.Errorf("cluster not running")
 	}
 
@@ -321,7 +525,7 @@ func (b *broadcast) Finished() {
 }
 
 func (c *Cluster) SendToNode(nodeName string, msg []byte) error {
-	if !c.running {
+	if !c.running.Load() {
 		return fmt.Errorf("cluster not running")
 	}
 
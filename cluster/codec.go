@@ -0,0 +1,248 @@
+package cluster
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kleeedolinux/gorilix/messaging"
+	pb "github.com/kleeedolinux/gorilix/proto"
+	"github.com/kleeedolinux/gorilix/serialization"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const (
+	CodecJSON     = "json"
+	CodecProtobuf = "protobuf"
+
+	MetaCodecs = "gorilix-codecs"
+
+	protobufFrameMarker = 0x00
+	protobufWireVersion = 1
+
+	payloadTypeURLPrefix = "type.gorilix.io/"
+	jsonPayloadTypeURL   = payloadTypeURLPrefix + "json"
+)
+
+type Codec interface {
+	Name() string
+	Encode(msg *messaging.Message) ([]byte, error)
+	Decode(data []byte) (*messaging.Message, error)
+}
+
+type JSONCodec struct{}
+
+func (JSONCodec) Name() string {
+	return CodecJSON
+}
+
+func (JSONCodec) Encode(msg *messaging.Message) ([]byte, error) {
+	return SerializeMessage(msg)
+}
+
+func (JSONCodec) Decode(data []byte) (*messaging.Message, error) {
+	return DeserializeMessage(data)
+}
+
+type ProtobufCodec struct{}
+
+func (ProtobufCodec) Name() string {
+	return CodecProtobuf
+}
+
+func (ProtobufCodec) Encode(msg *messaging.Message) ([]byte, error) {
+	payload, err := packPayload(msg.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := proto.Marshal(&pb.ClusterMessage{
+		Id:        msg.ID,
+		Type:      pb.MessageType(msg.Type),
+		Sender:    msg.Sender,
+		Receiver:  msg.Receiver,
+		Timestamp: timestamppb.New(msg.Timestamp),
+		Payload:   payload,
+		Headers:   msg.Headers,
+		Topic:     msg.Headers[HeaderTopic],
+		NodeId:    msg.Headers[HeaderSourceNode],
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	return append([]byte{protobufFrameMarker, protobufWireVersion}, data...), nil
+}
+
+func (ProtobufCodec) Decode(data []byte) (*messaging.Message, error) {
+	if len(data) < 2 || data[0] != protobufFrameMarker {
+		return nil, messaging.ErrInvalidMessageFormat
+	}
+	if data[1] != protobufWireVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedWireVersion, data[1])
+	}
+
+	var wire pb.ClusterMessage
+	if err := proto.Unmarshal(data[2:], &wire); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal message: %w", err)
+	}
+
+	msg := &messaging.Message{
+		ID:        wire.Id,
+		Type:      messaging.MessageType(wire.Type),
+		Sender:    wire.Sender,
+		Receiver:  wire.Receiver,
+		Timestamp: wire.Timestamp.AsTime(),
+		Headers:   wire.Headers,
+	}
+
+	if manifest, registered, err := parsePayloadTypeURL(wire.Payload); err != nil {
+		return nil, err
+	} else if registered {
+		return msg, decodeRegistered(msg, serialization.Encoded{Manifest: manifest, Data: wire.Payload.Value})
+	}
+
+	payload, err := unpackPayload(wire.Payload)
+	if err != nil {
+		return nil, err
+	}
+	msg.Payload = payload
+	return msg, nil
+}
+
+func CodecByName(name string) (Codec, error) {
+	switch name {
+	case "", CodecJSON:
+		return JSONCodec{}, nil
+	case CodecProtobuf:
+		return ProtobufCodec{}, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownCodec, name)
+}
+
+func supportedCodecs() string {
+	return strings.Join([]string{CodecJSON, CodecProtobuf}, ",")
+}
+
+func decodeFrame(data []byte) (*messaging.Message, error) {
+	if len(data) > 0 && data[0] == protobufFrameMarker {
+		return ProtobufCodec{}.Decode(data)
+	}
+	return JSONCodec{}.Decode(data)
+}
+
+func (c *Cluster) codecFor(nodeName string) Codec {
+	if c.codec.Name() == CodecJSON || nodeName == c.config.NodeName {
+		return c.codec
+	}
+
+	c.nodesMutex.RLock()
+	defer c.nodesMutex.RUnlock()
+
+	if nodeName == "" {
+		for _, node := range c.nodes {
+			if node.Name != c.config.NodeName && !nodeSupports(node, c.codec.Name()) {
+				return JSONCodec{}
+			}
+		}
+		return c.codec
+	}
+
+	if node, exists := c.nodes[nodeName]; exists && nodeSupports(node, c.codec.Name()) {
+		return c.codec
+	}
+	return JSONCodec{}
+}
+
+func nodeSupports(node *Node, codec string) bool {
+	for _, name := range strings.Split(node.Meta[MetaCodecs], ",") {
+		if name == codec {
+			return true
+		}
+	}
+	return false
+}
+
+func packPayload(payload interface{}) (*anypb.Any, error) {
+	if encoded, registered, err := encodeRegistered(payload); err != nil {
+		return nil, err
+	} else if registered {
+		return &anypb.Any{
+			TypeUrl: fmt.Sprintf("%s%s/%d/%s", payloadTypeURLPrefix, encoded.Serializer, encoded.Version, encoded.Type),
+			Value:   encoded.Data,
+		}, nil
+	}
+
+	var value proto.Message
+	switch p := payload.(type) {
+	case nil:
+		return nil, nil
+	case proto.Message:
+		value = p
+	case []byte:
+		value = wrapperspb.Bytes(p)
+	case string:
+		value = wrapperspb.String(p)
+	default:
+		data, err := json.Marshal(p)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize payload: %w", err)
+		}
+		return &anypb.Any{TypeUrl: jsonPayloadTypeURL, Value: data}, nil
+	}
+
+	packed, err := anypb.New(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize payload: %w", err)
+	}
+	return packed, nil
+}
+
+func parsePayloadTypeURL(payload *anypb.Any) (serialization.Manifest, bool, error) {
+	if payload == nil || payload.TypeUrl == jsonPayloadTypeURL {
+		return serialization.Manifest{}, false, nil
+	}
+
+	manifest, found := strings.CutPrefix(payload.TypeUrl, payloadTypeURLPrefix)
+	if !found {
+		return serialization.Manifest{}, false, nil
+	}
+
+	parts := strings.SplitN(manifest, "/", 3)
+	if len(parts) != 3 {
+		return serialization.Manifest{}, false, fmt.Errorf("%w: malformed type URL %s", messaging.ErrInvalidMessageFormat, payload.TypeUrl)
+	}
+	version, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return serialization.Manifest{}, false, fmt.Errorf("%w: malformed type URL %s", messaging.ErrInvalidMessageFormat, payload.TypeUrl)
+	}
+
+	return serialization.Manifest{Type: parts[2], Serializer: parts[0], Version: version}, true, nil
+}
+
+func unpackPayload(payload *anypb.Any) (interface{}, error) {
+	if payload == nil {
+		return nil, nil
+	}
+
+	if payload.TypeUrl == jsonPayloadTypeURL {
+		return payload.Value, nil
+	}
+
+	value, err := payload.UnmarshalNew()
+	if err != nil {
+		return nil, fmt.Errorf("failed to deserialize payload: %w", err)
+	}
+
+	switch v := value.(type) {
+	case *wrapperspb.BytesValue:
+		return v.Value, nil
+	case *wrapperspb.StringValue:
+		return v.Value, nil
+	}
+	return value, nil
+}
@@ -0,0 +1,122 @@
+package cluster
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kleeedolinux/gorilix/messaging"
+	"github.com/kleeedolinux/gorilix/serialization"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestProtobufCodecRoundTrip(t *testing.T) {
+	sent := timestamppb.New(time.Unix(1700000000, 0))
+	msg := &messaging.Message{
+		ID:        "m-1",
+		Type:      messaging.System,
+		Sender:    "n1",
+		Receiver:  "worker",
+		Timestamp: time.Unix(0, 42),
+		Headers:   map[string]string{HeaderSourceNode: "n1"},
+		Payload:   sent,
+	}
+
+	data, err := ProtobufCodec{}.Encode(msg)
+	if err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+
+	decoded, err := decodeFrame(data)
+	if err != nil {
+		t.Fatalf("decodeFrame failed: %v", err)
+	}
+
+	payload, ok := decoded.Payload.(*timestamppb.Timestamp)
+	if !ok {
+		t.Fatalf("Expected *timestamppb.Timestamp payload, got %T", decoded.Payload)
+	}
+	if !payload.AsTime().Equal(sent.AsTime()) {
+		t.Errorf("Expected payload %v, got %v", sent.AsTime(), payload.AsTime())
+	}
+	if decoded.ID != msg.ID || decoded.Receiver != msg.Receiver || !decoded.Timestamp.Equal(msg.Timestamp) {
+		t.Errorf("Envelope fields did not survive round trip: %+v", decoded)
+	}
+
+	legacy, err := JSONCodec{}.Encode(&messaging.Message{ID: "m-2", Payload: "hello"})
+	if err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+	if decoded, err := decodeFrame(legacy); err != nil || decoded.ID != "m-2" {
+		t.Errorf("Expected JSON frame to decode, got %+v, %v", decoded, err)
+	}
+}
+
+func TestCodecFallsBackToJSONForLegacyPeers(t *testing.T) {
+	c := NewCluster(&ClusterConfig{NodeName: "n1", Codec: ProtobufCodec{}}, nil)
+	c.nodes["n2"] = &Node{Name: "n2", Meta: map[string]string{MetaCodecs: "json,protobuf"}}
+	c.nodes["legacy"] = &Node{Name: "legacy", Meta: map[string]string{}}
+
+	if codec := c.codecFor("n2"); codec.Name() != CodecProtobuf {
+		t.Errorf("Expected protobuf for n2, got %s", codec.Name())
+	}
+	if codec := c.codecFor("legacy"); codec.Name() != CodecJSON {
+		t.Errorf("Expected json for legacy node, got %s", codec.Name())
+	}
+	if codec := c.codecFor(""); codec.Name() != CodecJSON {
+		t.Errorf("Expected json for broadcasts in a mixed cluster, got %s", codec.Name())
+	}
+}
+
+type registeredPayload struct {
+	Name  string
+	Count int
+}
+
+func TestCodecsPreserveRegisteredPayloadTypes(t *testing.T) {
+	if err := serialization.RegisterWith("cluster.registeredPayload", registeredPayload{}, serialization.MsgPack); err != nil {
+		t.Fatalf("RegisterWith failed: %v", err)
+	}
+
+	sent := registeredPayload{Name: "jobs", Count: 7}
+	for _, codec := range []Codec{JSONCodec{}, ProtobufCodec{}} {
+		data, err := codec.Encode(&messaging.Message{ID: "m-3", Payload: sent})
+		if err != nil {
+			t.Fatalf("%s Encode failed: %v", codec.Name(), err)
+		}
+
+		decoded, err := decodeFrame(data)
+		if err != nil {
+			t.Fatalf("%s decodeFrame failed: %v", codec.Name(), err)
+		}
+		if payload, ok := decoded.Payload.(registeredPayload); !ok || payload != sent {
+			t.Errorf("Expected %+v over %s, got %#v", sent, codec.Name(), decoded.Payload)
+		}
+	}
+}
+
+func TestUndecodablePayloadGoesToDeadLetters(t *testing.T) {
+	c := NewCluster(&ClusterConfig{NodeName: "n1"}, nil)
+
+	frame, err := JSONCodec{}.Encode(&messaging.Message{
+		ID:      "m-4",
+		Headers: map[string]string{HeaderSourceNode: "n2"},
+		Payload: serialization.Encoded{
+			Manifest: serialization.Manifest{Type: "cluster.unknownPayload", Serializer: serialization.JSON, Version: 2},
+			Data:     []byte(`{}`),
+		},
+	})
+	if err != nil {
+		t.Fatalf("Encode failed: %v", err)
+	}
+
+	c.handleFrame(frame)
+
+	select {
+	case letter := <-c.DeadLetters():
+		if letter.Node != "n2" || letter.Message.ID != "m-4" || letter.Manifest.Version != 2 {
+			t.Errorf("Unexpected dead letter: %+v", letter)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected undecodable message in dead letters")
+	}
+}
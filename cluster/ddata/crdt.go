@@ -0,0 +1,340 @@
+package ddata
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	TypeGCounter  = "gcounter"
+	TypePNCounter = "pncounter"
+	TypeORSet     = "orset"
+	TypeLWWMap    = "lwwmap"
+	TypeFlag      = "flag"
+)
+
+type CRDT interface {
+	Type() string
+	Merge(other CRDT) CRDT
+	Clone() CRDT
+}
+
+type GCounter struct {
+	Counts map[string]uint64 `json:"counts"`
+}
+
+func NewGCounter() *GCounter {
+	return &GCounter{Counts: make(map[string]uint64)}
+}
+
+func (g *GCounter) Type() string {
+	return TypeGCounter
+}
+
+func (g *GCounter) Increment(node string, n uint64) *GCounter {
+	g.Counts[node] += n
+	return g
+}
+
+func (g *GCounter) Value() uint64 {
+	var total uint64
+	for _, count := range g.Counts {
+		total += count
+	}
+	return total
+}
+
+func (g *GCounter) Merge(other CRDT) CRDT {
+	merged := g.Clone().(*GCounter)
+	for node, count := range other.(*GCounter).Counts {
+		if count > merged.Counts[node] {
+			merged.Counts[node] = count
+		}
+	}
+	return merged
+}
+
+func (g *GCounter) Clone() CRDT {
+	clone := NewGCounter()
+	for node, count := range g.Counts {
+		clone.Counts[node] = count
+	}
+	return clone
+}
+
+type PNCounter struct {
+	P *GCounter `json:"p"`
+	N *GCounter `json:"n"`
+}
+
+func NewPNCounter() *PNCounter {
+	return &PNCounter{P: NewGCounter(), N: NewGCounter()}
+}
+
+func (c *PNCounter) Type() string {
+	return TypePNCounter
+}
+
+func (c *PNCounter) Increment(node string, n int64) *PNCounter {
+	if n < 0 {
+		c.N.Increment(node, uint64(-n))
+	} else {
+		c.P.Increment(node, uint64(n))
+	}
+	return c
+}
+
+func (c *PNCounter) Decrement(node string, n int64) *PNCounter {
+	return c.Increment(node, -n)
+}
+
+func (c *PNCounter) Value() int64 {
+	return int64(c.P.Value()) - int64(c.N.Value())
+}
+
+func (c *PNCounter) Merge(other CRDT) CRDT {
+	o := other.(*PNCounter)
+	return &PNCounter{
+		P: c.P.Merge(o.P).(*GCounter),
+		N: c.N.Merge(o.N).(*GCounter),
+	}
+}
+
+func (c *PNCounter) Clone() CRDT {
+	return &PNCounter{P: c.P.Clone().(*GCounter), N: c.N.Clone().(*GCounter)}
+}
+
+// ORSet is an observed-remove set: a remove wins over the adds it observed, not over concurrent ones.
+type ORSet struct {
+	Clock    map[string]uint64            `json:"clock"`
+	Elements map[string]map[string]uint64 `json:"elements"`
+}
+
+func NewORSet() *ORSet {
+	return &ORSet{
+		Clock:    make(map[string]uint64),
+		Elements: make(map[string]map[string]uint64),
+	}
+}
+
+func (s *ORSet) Type() string {
+	return TypeORSet
+}
+
+func (s *ORSet) Add(node, element string) *ORSet {
+	s.Clock[node]++
+	s.Elements[element] = map[string]uint64{node: s.Clock[node]}
+	return s
+}
+
+func (s *ORSet) Remove(element string) *ORSet {
+	delete(s.Elements, element)
+	return s
+}
+
+func (s *ORSet) Contains(element string) bool {
+	_, exists := s.Elements[element]
+	return exists
+}
+
+func (s *ORSet) Values() []string {
+	values := make([]string, 0, len(s.Elements))
+	for element := range s.Elements {
+		values = append(values, element)
+	}
+	sort.Strings(values)
+	return values
+}
+
+func (s *ORSet) Merge(other CRDT) CRDT {
+	o := other.(*ORSet)
+	merged := NewORSet()
+
+	for node, seq := range s.Clock {
+		merged.Clock[node] = seq
+	}
+	for node, seq := range o.Clock {
+		if seq > merged.Clock[node] {
+			merged.Clock[node] = seq
+		}
+	}
+
+	keep := func(element string, ours, theirs map[string]uint64, theirClock map[string]uint64) {
+		for node, seq := range ours {
+			if theirs[node] == seq || seq > theirClock[node] {
+				if merged.Elements[element] == nil {
+					merged.Elements[element] = make(map[string]uint64)
+				}
+				merged.Elements[element][node] = seq
+			}
+		}
+	}
+	for element, dots := range s.Elements {
+		keep(element, dots, o.Elements[element], o.Clock)
+	}
+	for element, dots := range o.Elements {
+		keep(element, dots, s.Elements[element], s.Clock)
+	}
+	return merged
+}
+
+func (s *ORSet) Clone() CRDT {
+	return s.Merge(NewORSet())
+}
+
+type LWWEntry struct {
+	Value     json.RawMessage `json:"value,omitempty"`
+	Deleted   bool            `json:"deleted,omitempty"`
+	Timestamp int64           `json:"timestamp"`
+	Node      string          `json:"node"`
+}
+
+func (e LWWEntry) newerThan(other LWWEntry) bool {
+	if e.Timestamp != other.Timestamp {
+		return e.Timestamp > other.Timestamp
+	}
+	return e.Node > other.Node
+}
+
+type LWWMap struct {
+	Entries map[string]LWWEntry `json:"entries"`
+}
+
+func NewLWWMap() *LWWMap {
+	return &LWWMap{Entries: make(map[string]LWWEntry)}
+}
+
+func (m *LWWMap) Type() string {
+	return TypeLWWMap
+}
+
+func (m *LWWMap) Put(node, key string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode value for %s: %w", key, err)
+	}
+	m.Entries[key] = LWWEntry{Value: data, Timestamp: nextTimestamp(m.Entries[key].Timestamp), Node: node}
+	return nil
+}
+
+func (m *LWWMap) Delete(node, key string) {
+	m.Entries[key] = LWWEntry{Deleted: true, Timestamp: nextTimestamp(m.Entries[key].Timestamp), Node: node}
+}
+
+func (m *LWWMap) Get(key string, v interface{}) (bool, error) {
+	entry, exists := m.Entries[key]
+	if !exists || entry.Deleted {
+		return false, nil
+	}
+	return true, json.Unmarshal(entry.Value, v)
+}
+
+func (m *LWWMap) Keys() []string {
+	keys := make([]string, 0, len(m.Entries))
+	for key, entry := range m.Entries {
+		if !entry.Deleted {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (m *LWWMap) Merge(other CRDT) CRDT {
+	merged := m.Clone().(*LWWMap)
+	for key, entry := range other.(*LWWMap).Entries {
+		if current, exists := merged.Entries[key]; !exists || entry.newerThan(current) {
+			merged.Entries[key] = entry
+		}
+	}
+	return merged
+}
+
+func (m *LWWMap) Clone() CRDT {
+	clone := NewLWWMap()
+	for key, entry := range m.Entries {
+		clone.Entries[key] = entry
+	}
+	return clone
+}
+
+type Flag struct {
+	Enabled   bool   `json:"enabled"`
+	Timestamp int64  `json:"timestamp"`
+	Node      string `json:"node"`
+}
+
+func NewFlag() *Flag {
+	return &Flag{}
+}
+
+func (f *Flag) Type() string {
+	return TypeFlag
+}
+
+func (f *Flag) Set(node string, enabled bool) *Flag {
+	f.Enabled = enabled
+	f.Timestamp = nextTimestamp(f.Timestamp)
+	f.Node = node
+	return f
+}
+
+func (f *Flag) Merge(other CRDT) CRDT {
+	o := other.(*Flag)
+	current := LWWEntry{Timestamp: f.Timestamp, Node: f.Node}
+	if (LWWEntry{Timestamp: o.Timestamp, Node: o.Node}).newerThan(current) {
+		return o.Clone()
+	}
+	return f.Clone()
+}
+
+func (f *Flag) Clone() CRDT {
+	clone := *f
+	return &clone
+}
+
+func nextTimestamp(previous int64) int64 {
+	now := time.Now().UnixNano()
+	if now <= previous {
+		return previous + 1
+	}
+	return now
+}
+
+type envelope struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+func encode(data CRDT) (envelope, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return envelope{}, err
+	}
+	return envelope{Type: data.Type(), Data: raw}, nil
+}
+
+func decode(env envelope) (CRDT, error) {
+	var data CRDT
+	switch env.Type {
+	case TypeGCounter:
+		data = NewGCounter()
+	case TypePNCounter:
+		data = NewPNCounter()
+	case TypeORSet:
+		data = NewORSet()
+	case TypeLWWMap:
+		data = NewLWWMap()
+	case TypeFlag:
+		data = NewFlag()
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownType, env.Type)
+	}
+
+	if err := json.Unmarshal(env.Data, data); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", env.Type, err)
+	}
+	return data, nil
+}
@@ -0,0 +1,77 @@
+package ddata
+
+import "testing"
+
+func roundTrip(t *testing.T, data CRDT) CRDT {
+	t.Helper()
+	env, err := encode(data)
+	if err != nil {
+		t.Fatalf("Failed to encode %s: %v", data.Type(), err)
+	}
+	decoded, err := decode(env)
+	if err != nil {
+		t.Fatalf("Failed to decode %s: %v", data.Type(), err)
+	}
+	return decoded
+}
+
+func TestCountersConverge(t *testing.T) {
+	a := NewPNCounter().Increment("n1", 5)
+	b := NewPNCounter().Increment("n2", 3).Decrement("n2", 4)
+
+	ab := a.Merge(roundTrip(t, b)).(*PNCounter)
+	ba := b.Merge(roundTrip(t, a)).(*PNCounter)
+	if ab.Value() != 4 || ba.Value() != 4 {
+		t.Errorf("Expected both replicas to count 4, got %d and %d", ab.Value(), ba.Value())
+	}
+
+	if again := ab.Merge(ba).(*PNCounter); again.Value() != 4 {
+		t.Errorf("Expected merging twice to be idempotent, got %d", again.Value())
+	}
+}
+
+func TestORSetObservedRemove(t *testing.T) {
+	a := NewORSet().Add("n1", "x").Add("n1", "y")
+	b := roundTrip(t, a).(*ORSet)
+
+	b.Remove("x")
+	a.Add("n1", "y")
+	a.Add("n1", "z")
+
+	merged := a.Merge(b).(*ORSet)
+	if merged.Contains("x") {
+		t.Error("Expected the observed remove of x to win")
+	}
+	if !merged.Contains("y") || !merged.Contains("z") {
+		t.Errorf("Expected concurrent adds to survive, got %v", merged.Values())
+	}
+
+	b.Remove("y")
+	merged = merged.Merge(b).(*ORSet)
+	if !merged.Contains("y") {
+		t.Error("Expected y to survive a remove that did not observe its latest add")
+	}
+}
+
+func TestLastWriterWins(t *testing.T) {
+	a := NewLWWMap()
+	a.Put("n1", "color", "red")
+	b := roundTrip(t, a).(*LWWMap)
+	b.Put("n2", "color", "blue")
+	a.Delete("n1", "size")
+
+	var color string
+	merged := a.Merge(b).(*LWWMap)
+	if found, err := merged.Get("color", &color); !found || err != nil || color != "blue" {
+		t.Errorf("Expected the later write to win, got %q (%v, %v)", color, found, err)
+	}
+	if keys := merged.Keys(); len(keys) != 1 {
+		t.Errorf("Expected deleted keys to be hidden, got %v", keys)
+	}
+
+	on := NewFlag().Set("n1", true)
+	off := roundTrip(t, on).(*Flag).Set("n2", false)
+	if on.Merge(off).(*Flag).Enabled || off.Merge(on).(*Flag).Enabled {
+		t.Error("Expected the later flag update to win")
+	}
+}
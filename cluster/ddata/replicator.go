@@ -0,0 +1,384 @@
+package ddata
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kleeedolinux/gorilix/actor"
+	"github.com/kleeedolinux/gorilix/cluster"
+	"github.com/kleeedolinux/gorilix/messaging"
+)
+
+const (
+	KindWrite = "ddata-write"
+	KindRead  = "ddata-read"
+
+	stateName = "ddata"
+)
+
+var (
+	ErrNotFound = errors.New("ddata key not found")
+
+	ErrTypeMismatch = errors.New("ddata type mismatch")
+
+	ErrUnknownType = errors.New("unknown ddata type")
+
+	ErrConsistencyNotReached = errors.New("ddata consistency level not reached")
+)
+
+type Consistency int
+
+const (
+	Local Consistency = iota
+	Majority
+	All
+)
+
+type Changed struct {
+	Key  string
+	Data CRDT
+}
+
+type writeRequest struct {
+	Key   string   `json:"key"`
+	Value envelope `json:"value"`
+}
+
+type readRequest struct {
+	Key string `json:"key"`
+}
+
+type readReply struct {
+	Found bool     `json:"found"`
+	Value envelope `json:"value"`
+}
+
+type notification struct {
+	subscribers []actor.ActorRef
+	key         string
+	data        CRDT
+}
+
+type Replicator struct {
+	cluster     *cluster.Cluster
+	data        map[string]CRDT
+	subscribers map[string][]actor.ActorRef
+	queue       []notification
+	queueMu     sync.Mutex
+	wake        chan struct{}
+	mu          sync.RWMutex
+	subsMu      sync.RWMutex
+}
+
+func NewReplicator(c *cluster.Cluster) *Replicator {
+	r := &Replicator{
+		cluster:     c,
+		data:        make(map[string]CRDT),
+		subscribers: make(map[string][]actor.ActorRef),
+		wake:        make(chan struct{}, 1),
+	}
+
+	c.HandleRequest(KindWrite, r.handleWrite)
+	c.HandleRequest(KindRead, r.handleRead)
+	c.RegisterState(stateName, r)
+	c.OnStart(func() { go r.run() })
+
+	return r
+}
+
+func (r *Replicator) Node() string {
+	return r.cluster.NodeName()
+}
+
+func (r *Replicator) Keys() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	keys := make([]string, 0, len(r.data))
+	for key := range r.data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (r *Replicator) Update(ctx context.Context, key string, initial CRDT, write Consistency, modify func(CRDT) (CRDT, error)) error {
+	r.mu.Lock()
+	current, exists := r.data[key]
+	if !exists {
+		current = initial
+	} else if current.Type() != initial.Type() {
+		r.mu.Unlock()
+		return fmt.Errorf("%w: %s is a %s", ErrTypeMismatch, key, current.Type())
+	}
+
+	updated, err := modify(current.Clone())
+	if err != nil {
+		r.mu.Unlock()
+		return err
+	}
+	r.data[key] = updated
+	r.mu.Unlock()
+
+	r.notify(key, updated)
+
+	if write == Local {
+		return nil
+	}
+
+	value, err := encode(updated)
+	if err != nil {
+		return err
+	}
+
+	replies := r.broadcast(ctx, KindWrite, writeRequest{Key: key, Value: value})
+	if !r.reached(write, 1+len(replies)) {
+		return fmt.Errorf("%w: %s", ErrConsistencyNotReached, key)
+	}
+	return nil
+}
+
+func (r *Replicator) Get(ctx context.Context, key string, read Consistency) (CRDT, error) {
+	if read != Local {
+		replies := r.broadcast(ctx, KindRead, readRequest{Key: key})
+		for _, reply := range replies {
+			var result readReply
+			if err := cluster.DecodePayload(reply, &result); err != nil || !result.Found {
+				continue
+			}
+			if data, err := decode(result.Value); err == nil {
+				r.merge(key, data)
+			}
+		}
+		if !r.reached(read, 1+len(replies)) {
+			return nil, fmt.Errorf("%w: %s", ErrConsistencyNotReached, key)
+		}
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	data, exists := r.data[key]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	return data.Clone(), nil
+}
+
+func (r *Replicator) Subscribe(key string, subscriber actor.ActorRef) {
+	r.subsMu.Lock()
+	defer r.subsMu.Unlock()
+
+	for _, sub := range r.subscribers[key] {
+		if sub.ID() == subscriber.ID() {
+			return
+		}
+	}
+	r.subscribers[key] = append(r.subscribers[key], subscriber)
+}
+
+func (r *Replicator) Unsubscribe(key string, subscriberID string) {
+	r.subsMu.Lock()
+	defer r.subsMu.Unlock()
+
+	var subs []actor.ActorRef
+	for _, sub := range r.subscribers[key] {
+		if sub.ID() != subscriberID {
+			subs = append(subs, sub)
+		}
+	}
+	if len(subs) == 0 {
+		delete(r.subscribers, key)
+	} else {
+		r.subscribers[key] = subs
+	}
+}
+
+func (r *Replicator) peers() []string {
+	var peers []string
+	for _, node := range r.cluster.Members() {
+		if node.Name != r.cluster.NodeName() && node.Status == cluster.NodeAlive {
+			peers = append(peers, node.Name)
+		}
+	}
+	return peers
+}
+
+func (r *Replicator) reached(level Consistency, acks int) bool {
+	size := len(r.peers()) + 1
+	switch level {
+	case Majority:
+		return acks >= size/2+1
+	case All:
+		return acks >= size
+	}
+	return true
+}
+
+func (r *Replicator) broadcast(ctx context.Context, kind string, payload interface{}) []*messaging.Message {
+	peers := r.peers()
+	results := make(chan *messaging.Message, len(peers))
+
+	var wg sync.WaitGroup
+	for _, peer := range peers {
+		wg.Add(1)
+		go func(peer string) {
+			defer wg.Done()
+			if reply, err := r.cluster.Request(ctx, peer, kind, payload); err == nil {
+				results <- reply
+			}
+		}(peer)
+	}
+	wg.Wait()
+	close(results)
+
+	var replies []*messaging.Message
+	for reply := range results {
+		replies = append(replies, reply)
+	}
+	return replies
+}
+
+func (r *Replicator) handleWrite(ctx context.Context, msg *messaging.Message) (interface{}, error) {
+	var request writeRequest
+	if err := cluster.DecodePayload(msg, &request); err != nil {
+		return nil, err
+	}
+
+	data, err := decode(request.Value)
+	if err != nil {
+		return nil, err
+	}
+	return nil, r.merge(request.Key, data)
+}
+
+func (r *Replicator) handleRead(ctx context.Context, msg *messaging.Message) (interface{}, error) {
+	var request readRequest
+	if err := cluster.DecodePayload(msg, &request); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	data, exists := r.data[request.Key]
+	r.mu.RUnlock()
+
+	if !exists {
+		return readReply{}, nil
+	}
+	value, err := encode(data)
+	if err != nil {
+		return nil, err
+	}
+	return readReply{Found: true, Value: value}, nil
+}
+
+func (r *Replicator) merge(key string, remote CRDT) error {
+	r.mu.Lock()
+	current, exists := r.data[key]
+	if exists && current.Type() != remote.Type() {
+		r.mu.Unlock()
+		return fmt.Errorf("%w: %s is a %s", ErrTypeMismatch, key, current.Type())
+	}
+
+	merged := remote
+	if exists {
+		merged = current.Merge(remote)
+		if equal(current, merged) {
+			r.mu.Unlock()
+			return nil
+		}
+	}
+	r.data[key] = merged
+	r.mu.Unlock()
+
+	r.notify(key, merged)
+	return nil
+}
+
+func (r *Replicator) notify(key string, data CRDT) {
+	r.subsMu.RLock()
+	subscribers := append([]actor.ActorRef(nil), r.subscribers[key]...)
+	r.subsMu.RUnlock()
+
+	if len(subscribers) == 0 {
+		return
+	}
+
+	r.queueMu.Lock()
+	r.queue = append(r.queue, notification{subscribers: subscribers, key: key, data: data.Clone()})
+	r.queueMu.Unlock()
+
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (r *Replicator) deliver() {
+	r.queueMu.Lock()
+	queue := r.queue
+	r.queue = nil
+	r.queueMu.Unlock()
+
+	for _, n := range queue {
+		for _, sub := range n.subscribers {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			_ = sub.Send(ctx, &Changed{Key: n.key, Data: n.data.Clone()})
+			cancel()
+		}
+	}
+}
+
+func (r *Replicator) run() {
+	for {
+		select {
+		case <-r.cluster.Done():
+			return
+		case <-r.wake:
+			r.deliver()
+		}
+	}
+}
+
+func (r *Replicator) LocalState() []byte {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	state := make(map[string]envelope, len(r.data))
+	for key, data := range r.data {
+		if value, err := encode(data); err == nil {
+			state[key] = value
+		}
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+func (r *Replicator) MergeRemoteState(data []byte) {
+	var state map[string]envelope
+	if err := json.Unmarshal(data, &state); err != nil {
+		return
+	}
+
+	for key, value := range state {
+		if remote, err := decode(value); err == nil {
+			_ = r.merge(key, remote)
+		}
+	}
+}
+
+func equal(a, b CRDT) bool {
+	ea, errA := json.Marshal(a)
+	eb, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(ea, eb)
+}
@@ -0,0 +1,132 @@
+package ddata
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kleeedolinux/gorilix/cluster"
+	"github.com/kleeedolinux/gorilix/cluster/internal/clustertest"
+)
+
+func newNode(t *testing.T, name string) (*cluster.Cluster, *Replicator) {
+	t.Helper()
+
+	c := cluster.NewCluster(clustertest.Config(name), nil)
+	r := NewReplicator(c)
+	clustertest.Start(t, c)
+	return c, r
+}
+
+func increment(r *Replicator, n uint64) func(CRDT) (CRDT, error) {
+	return func(data CRDT) (CRDT, error) {
+		return data.(*GCounter).Increment(r.Node(), n), nil
+	}
+}
+
+func counter(t *testing.T, r *Replicator, key string, read Consistency) uint64 {
+	t.Helper()
+
+	data, err := r.Get(context.Background(), key, read)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	return data.(*GCounter).Value()
+}
+
+func TestUpdateAndGetWithConsistency(t *testing.T) {
+	c1, r1 := newNode(t, "n1")
+	c2, r2 := newNode(t, "n2")
+	clustertest.Join(t, c2, c1)
+	clustertest.WaitFor(t, "both nodes to see two members", func() bool {
+		return clustertest.Alive(c1) == 2 && clustertest.Alive(c2) == 2
+	})
+
+	ctx := context.Background()
+	if err := r1.Update(ctx, "views", NewGCounter(), Majority, increment(r1, 2)); err != nil {
+		t.Fatalf("Update at Majority failed: %v", err)
+	}
+	if got := counter(t, r2, "views", Local); got != 2 {
+		t.Errorf("Expected n2 to hold 2 after a majority write, got %d", got)
+	}
+
+	if err := r2.Update(ctx, "views", NewGCounter(), All, increment(r2, 3)); err != nil {
+		t.Fatalf("Update at All failed: %v", err)
+	}
+	if got := counter(t, r1, "views", Local); got != 5 {
+		t.Errorf("Expected n1 to hold 5 after a write to all, got %d", got)
+	}
+
+	if err := r1.Update(ctx, "views", NewGCounter(), Local, increment(r1, 1)); err != nil {
+		t.Fatalf("Update at Local failed: %v", err)
+	}
+	if got := counter(t, r2, "views", Local); got != 5 {
+		t.Errorf("Expected a local write to stay on n1, n2 holds %d", got)
+	}
+	if got := counter(t, r2, "views", Majority); got != 6 {
+		t.Errorf("Expected a majority read to merge n1's value, got %d", got)
+	}
+
+	if _, err := r1.Get(ctx, "missing", All); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+	if err := r1.Update(ctx, "views", NewFlag(), Local, func(data CRDT) (CRDT, error) { return data, nil }); !errors.Is(err, ErrTypeMismatch) {
+		t.Errorf("Expected ErrTypeMismatch, got %v", err)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := r1.Update(cancelled, "views", NewGCounter(), All, increment(r1, 1)); !errors.Is(err, ErrConsistencyNotReached) {
+		t.Errorf("Expected ErrConsistencyNotReached, got %v", err)
+	}
+	if got := counter(t, r1, "views", Local); got != 7 {
+		t.Errorf("Expected the local update to apply anyway, got %d", got)
+	}
+}
+
+func TestMergeRemoteStateConvergesAndNotifies(t *testing.T) {
+	_, r1 := newNode(t, "n1")
+	_, r2 := newNode(t, "n2")
+
+	watcher := clustertest.NewRecorder("watcher")
+	r2.Subscribe("views", watcher)
+
+	ctx := context.Background()
+	if err := r1.Update(ctx, "views", NewGCounter(), Local, increment(r1, 4)); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	if err := r2.Update(ctx, "views", NewGCounter(), Local, increment(r2, 1)); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	r2.MergeRemoteState(r1.LocalState())
+	r1.MergeRemoteState(r2.LocalState())
+
+	if v1, v2 := counter(t, r1, "views", Local), counter(t, r2, "views", Local); v1 != 5 || v2 != 5 {
+		t.Errorf("Expected both replicas to converge on 5, got %d and %d", v1, v2)
+	}
+
+	for _, want := range []uint64{1, 5} {
+		select {
+		case msg := <-watcher.Messages:
+			changed, ok := msg.(*Changed)
+			if !ok || changed.Key != "views" || changed.Data.(*GCounter).Value() != want {
+				t.Fatalf("Expected views to change to %d, got %+v", want, msg)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Expected a change to %d", want)
+		}
+	}
+
+	r2.MergeRemoteState(r1.LocalState())
+	r2.Unsubscribe("views", watcher.ID())
+	if err := r2.Update(ctx, "views", NewGCounter(), Local, increment(r2, 1)); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	select {
+	case msg := <-watcher.Messages:
+		t.Errorf("Expected no change for an idempotent merge or after Unsubscribe, got %+v", msg)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
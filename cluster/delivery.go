@@ -0,0 +1,407 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/kleeedolinux/gorilix/messaging"
+)
+
+const (
+	KindAck = "delivery-ack"
+
+	HeaderSequence = "gorilix-seq"
+	HeaderSeqBase  = "gorilix-seq-base"
+	HeaderSession  = "gorilix-session"
+
+	redeliverInterval = 100 * time.Millisecond
+	maxReorderBuffer  = 1024
+)
+
+type outgoing struct {
+	node     string
+	msg      *messaging.Message
+	seq      uint64
+	interval time.Duration
+	sentAt   time.Time
+	deadline time.Time
+	done     chan error
+}
+
+type outbox struct {
+	next    uint64
+	pending map[string]*outgoing
+	mu      sync.Mutex
+}
+
+type received struct {
+	seq uint64
+	msg *messaging.Message
+	err error
+}
+
+type inbox struct {
+	session  string
+	next     uint64
+	base     uint64
+	buffered map[uint64]received
+	results  map[uint64]error
+}
+
+func newInbox(session string) *inbox {
+	return &inbox{
+		session:  session,
+		next:     1,
+		buffered: make(map[uint64]received),
+		results:  make(map[uint64]error),
+	}
+}
+
+// accept returns the messages now ready, in order, skipping gaps below the sender's base.
+func (in *inbox) accept(seq, base uint64, item received) (ready []received, duplicate bool) {
+	if base > in.base {
+		for s := range in.results {
+			if s < base {
+				delete(in.results, s)
+			}
+		}
+		in.base = base
+	}
+	if base > in.next {
+		for s := range in.buffered {
+			if s < base {
+				delete(in.buffered, s)
+			}
+		}
+		in.next = base
+	}
+
+	duplicate = seq < in.next
+	if !duplicate && len(in.buffered) < maxReorderBuffer {
+		item.seq = seq
+		in.buffered[seq] = item
+	}
+
+	for {
+		next, exists := in.buffered[in.next]
+		if !exists {
+			break
+		}
+		delete(in.buffered, in.next)
+		ready = append(ready, next)
+		in.next++
+	}
+	return ready, duplicate
+}
+
+// SendAcked always uses the acknowledged path and waits until the receiver has processed msg or ctx ends.
+// Broadcasts are gossiped without acknowledgements, so it rejects them with ErrBroadcastNotAcknowledged.
+func (c *Cluster) SendAcked(ctx context.Context, nodeName string, msg *messaging.Message) error {
+	if msg.Type == messaging.Broadcast {
+		return ErrBroadcastNotAcknowledged
+	}
+	if !c.running.Load() {
+		return ErrClusterNotRunning
+	}
+
+	out := c.prepare(msg)
+	if nodeName == c.config.NodeName {
+		return c.transmit(nodeName, out)
+	}
+
+	entry, err := c.sendSequenced(nodeName, out)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case err := <-entry.done:
+		return err
+	case <-ctx.Done():
+		c.abandon(entry)
+		return ctx.Err()
+	}
+}
+
+func (c *Cluster) deliver(ctx context.Context, nodeName string, msg *messaging.Message) error {
+	if c.ackedDelivery() && msg.Type != messaging.Broadcast {
+		return c.SendAcked(ctx, nodeName, msg)
+	}
+	return c.Send(nodeName, msg)
+}
+
+func (c *Cluster) ackedDelivery() bool {
+	if c.bus == nil {
+		return false
+	}
+	_, _, acked := c.bus.DeliveryOptions()
+	return acked
+}
+
+func (c *Cluster) sequenced(nodeName string, msg *messaging.Message) bool {
+	return nodeName != c.config.NodeName &&
+		msg.Type != messaging.System &&
+		msg.Type != messaging.Broadcast &&
+		c.ackedDelivery()
+}
+
+func (c *Cluster) sendSequenced(nodeName string, msg *messaging.Message) (*outgoing, error) {
+	timeout, retries := defaultDeliveryTimeout, 1
+	if c.bus != nil {
+		timeout, retries, _ = c.bus.DeliveryOptions()
+		if timeout <= 0 {
+			timeout = defaultDeliveryTimeout
+		}
+		if retries <= 0 {
+			retries = 1
+		}
+	}
+
+	c.outboxesMu.Lock()
+	box, exists := c.outboxes[nodeName]
+	if !exists {
+		box = &outbox{next: 1, pending: make(map[string]*outgoing)}
+		c.outboxes[nodeName] = box
+	}
+	c.outboxesMu.Unlock()
+
+	box.mu.Lock()
+	entry := &outgoing{
+		node:     nodeName,
+		msg:      msg,
+		seq:      box.next,
+		interval: timeout / time.Duration(retries),
+		deadline: time.Now().Add(timeout),
+		done:     make(chan error, 1),
+	}
+	box.next++
+	msg.Headers[HeaderSequence] = strconv.FormatUint(entry.seq, 10)
+	msg.Headers[HeaderSession] = c.session()
+
+	box.pending[msg.ID] = entry
+	out := box.stampLocked(entry)
+	box.mu.Unlock()
+
+	if err := c.transmitSequenced(entry.node, out); err != nil {
+		box.mu.Lock()
+		delete(box.pending, msg.ID)
+		box.mu.Unlock()
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (box *outbox) stampLocked(entry *outgoing) *messaging.Message {
+	base := entry.seq
+	for _, pending := range box.pending {
+		if pending.seq < base {
+			base = pending.seq
+		}
+	}
+	entry.sentAt = time.Now()
+
+	out := *entry.msg
+	out.Headers = make(map[string]string, len(entry.msg.Headers)+1)
+	for k, v := range entry.msg.Headers {
+		out.Headers[k] = v
+	}
+	out.Headers[HeaderSeqBase] = strconv.FormatUint(base, 10)
+	return &out
+}
+
+func (c *Cluster) transmitSequenced(nodeName string, msg *messaging.Message) error {
+	data, err := c.encode(nodeName, msg)
+	if err != nil {
+		return err
+	}
+	return c.SendToNode(nodeName, data)
+}
+
+func (c *Cluster) session() string {
+	c.delegates.mtx.RLock()
+	defer c.delegates.mtx.RUnlock()
+	return c.delegates.metadata[MetaIncarnation]
+}
+
+func (c *Cluster) redeliver() {
+	ticker := time.NewTicker(redeliverInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.stopCh:
+			return
+		case <-ticker.C:
+		}
+
+		c.outboxesMu.Lock()
+		boxes := make([]*outbox, 0, len(c.outboxes))
+		for _, box := range c.outboxes {
+			boxes = append(boxes, box)
+		}
+		c.outboxesMu.Unlock()
+
+		now := time.Now()
+		var expired []*outgoing
+		var due []outgoing
+		for _, box := range boxes {
+			box.mu.Lock()
+			for id, entry := range box.pending {
+				switch {
+				case now.After(entry.deadline):
+					delete(box.pending, id)
+					expired = append(expired, entry)
+				case now.Sub(entry.sentAt) >= entry.interval:
+					resend := *entry
+					resend.msg = box.stampLocked(entry)
+					due = append(due, resend)
+				}
+			}
+			box.mu.Unlock()
+		}
+
+		for _, resend := range due {
+			_ = c.transmitSequenced(resend.node, resend.msg)
+		}
+		for _, entry := range expired {
+			c.giveUp(entry, fmt.Errorf("%w: %s", ErrDeliveryTimeout, entry.msg.ID))
+		}
+	}
+}
+
+func (c *Cluster) giveUp(entry *outgoing, reason error) {
+	entry.done <- reason
+
+	select {
+	case c.deliveryFailures <- DeliveryFailure{
+		Node:      entry.node,
+		MessageID: entry.msg.ID,
+		Receiver:  entry.msg.Receiver,
+		Reason:    reason.Error(),
+	}:
+	default:
+
+	}
+}
+
+func (c *Cluster) abandon(entry *outgoing) {
+	c.outboxesMu.Lock()
+	box, exists := c.outboxes[entry.node]
+	c.outboxesMu.Unlock()
+
+	if exists {
+		box.mu.Lock()
+		delete(box.pending, entry.msg.ID)
+		box.mu.Unlock()
+	}
+}
+
+func (c *Cluster) handleAck(ctx context.Context, msg *messaging.Message) error {
+	node := msg.Headers[HeaderSourceNode]
+
+	c.outboxesMu.Lock()
+	box, exists := c.outboxes[node]
+	c.outboxesMu.Unlock()
+	if !exists {
+		return nil
+	}
+
+	box.mu.Lock()
+	entry, exists := box.pending[msg.Headers[HeaderCorrelationID]]
+	delete(box.pending, msg.Headers[HeaderCorrelationID])
+	box.mu.Unlock()
+	if !exists {
+		return nil
+	}
+
+	var err error
+	if errText, failed := msg.Headers[HeaderError]; failed {
+		err = &RemoteError{Node: node, Message: errText}
+	}
+	entry.done <- err
+	return nil
+}
+
+func (c *Cluster) receiveSequenced(msg *messaging.Message, decodeErr error) {
+	source := msg.Headers[HeaderSourceNode]
+	seq, err := strconv.ParseUint(msg.Headers[HeaderSequence], 10, 64)
+	if err != nil || source == "" {
+		_ = c.process(msg, decodeErr)
+		return
+	}
+	base, _ := strconv.ParseUint(msg.Headers[HeaderSeqBase], 10, 64)
+
+	c.inboxesMu.Lock()
+	in, exists := c.inboxes[source]
+	if !exists || in.session != msg.Headers[HeaderSession] {
+		in = newInbox(msg.Headers[HeaderSession])
+		c.inboxes[source] = in
+	}
+	ready, duplicate := in.accept(seq, base, received{msg: msg, err: decodeErr})
+	c.inboxesMu.Unlock()
+
+	if duplicate {
+		c.inboxesMu.Lock()
+		result, done := in.results[seq]
+		c.inboxesMu.Unlock()
+
+		if done {
+			c.ack(source, msg.ID, result)
+		}
+		return
+	}
+	for _, item := range ready {
+		err := c.process(item.msg, item.err)
+
+		c.inboxesMu.Lock()
+		in.results[item.seq] = err
+		c.inboxesMu.Unlock()
+
+		c.ack(source, item.msg.ID, err)
+	}
+}
+
+func (c *Cluster) ack(nodeName, id string, err error) {
+	ack := &messaging.Message{
+		Type:      messaging.System,
+		Timestamp: time.Now(),
+		Headers: map[string]string{
+			HeaderKind:          KindAck,
+			HeaderCorrelationID: id,
+		},
+	}
+	if err != nil {
+		ack.Headers[HeaderError] = err.Error()
+	}
+	_ = c.Send(nodeName, ack)
+}
+
+func (c *Cluster) dropDelivery(event NodeEvent) {
+	if event.Type != NodeDown {
+		return
+	}
+
+	c.inboxesMu.Lock()
+	delete(c.inboxes, event.Node)
+	c.inboxesMu.Unlock()
+
+	c.outboxesMu.Lock()
+	box, exists := c.outboxes[event.Node]
+	delete(c.outboxes, event.Node)
+	c.outboxesMu.Unlock()
+	if !exists {
+		return
+	}
+
+	box.mu.Lock()
+	pending := box.pending
+	box.pending = make(map[string]*outgoing)
+	box.mu.Unlock()
+
+	for _, entry := range pending {
+		c.giveUp(entry, fmt.Errorf("%w: %s", ErrNodeNotFound, event.Node))
+	}
+}
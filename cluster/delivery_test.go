@@ -0,0 +1,112 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/kleeedolinux/gorilix/messaging"
+)
+
+func TestInboxOrdering(t *testing.T) {
+	tests := []struct {
+		name       string
+		arrivals   []uint64
+		bases      []uint64
+		want       []string
+		duplicates int
+	}{
+		{"in order", []uint64{1, 2, 3}, []uint64{1, 1, 1}, []string{"1", "2", "3"}, 0},
+		{"reordered", []uint64{2, 3, 1}, []uint64{1, 1, 1}, []string{"1", "2", "3"}, 0},
+		{"resent duplicates", []uint64{1, 1, 2, 1}, []uint64{1, 1, 1, 1}, []string{"1", "2"}, 2},
+		{"abandoned gap skipped", []uint64{1, 3, 4}, []uint64{1, 3, 3}, []string{"1", "3", "4"}, 0},
+		{"gap waits for resend", []uint64{1, 3, 2}, []uint64{1, 2, 2}, []string{"1", "2", "3"}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := newInbox("session")
+
+			var delivered []string
+			duplicates := 0
+			for i, seq := range tt.arrivals {
+				msg := &messaging.Message{ID: strconv.FormatUint(seq, 10)}
+				ready, duplicate := in.accept(seq, tt.bases[i], received{msg: msg})
+				if duplicate {
+					duplicates++
+				}
+				for _, item := range ready {
+					delivered = append(delivered, item.msg.ID)
+				}
+			}
+
+			if !reflect.DeepEqual(delivered, tt.want) {
+				t.Errorf("delivered %v, want %v", delivered, tt.want)
+			}
+			if duplicates != tt.duplicates {
+				t.Errorf("got %d duplicates, want %d", duplicates, tt.duplicates)
+			}
+		})
+	}
+}
+
+func TestDuplicateIsAckedWithOriginalError(t *testing.T) {
+	c := NewCluster(&ClusterConfig{NodeName: "n1"}, nil)
+	calls := 0
+	c.HandleKind("fail", func(ctx context.Context, msg *messaging.Message) error {
+		calls++
+		return errors.New("boom")
+	})
+	startDispatch(t, c)
+
+	box := &outbox{next: 2, pending: make(map[string]*outgoing)}
+	c.outboxes["n1"] = box
+
+	msg := &messaging.Message{
+		ID: "m1",
+		Headers: map[string]string{
+			HeaderKind:       "fail",
+			HeaderSourceNode: "n1",
+			HeaderSequence:   "1",
+			HeaderSeqBase:    "1",
+			HeaderSession:    "session",
+		},
+	}
+
+	for attempt := 1; attempt <= 2; attempt++ {
+		entry := &outgoing{node: "n1", msg: msg, seq: 1, done: make(chan error, 1)}
+		box.mu.Lock()
+		box.pending[msg.ID] = entry
+		box.mu.Unlock()
+
+		c.receiveSequenced(msg, nil)
+
+		select {
+		case err := <-entry.done:
+			var remote *RemoteError
+			if !errors.As(err, &remote) || remote.Message != "boom" {
+				t.Errorf("Attempt %d: expected the handler error in the ack, got %v", attempt, err)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Attempt %d: expected an ack", attempt)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Expected the duplicate not to be processed again, handler ran %d times", calls)
+	}
+}
+
+func TestSendAckedRejectsBroadcasts(t *testing.T) {
+	c := NewCluster(&ClusterConfig{NodeName: "n1"}, nil)
+
+	err := c.SendAcked(context.Background(), "n2", &messaging.Message{Type: messaging.Broadcast})
+	if !errors.Is(err, ErrBroadcastNotAcknowledged) {
+		t.Errorf("Expected ErrBroadcastNotAcknowledged, got %v", err)
+	}
+	if len(c.outboxes) != 0 {
+		t.Errorf("Expected no sequenced entry for a broadcast, got %d outboxes", len(c.outboxes))
+	}
+}
@@ -0,0 +1,405 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+	"time"
+
+	"github.com/kleeedolinux/gorilix/actor"
+	"github.com/kleeedolinux/gorilix/messaging"
+)
+
+const (
+	HeaderKind          = "gorilix-kind"
+	HeaderSourceNode    = "gorilix-source-node"
+	HeaderReceiverType  = "gorilix-receiver-type"
+	HeaderCorrelationID = "gorilix-correlation-id"
+)
+
+const (
+	ReceiverByID   = "id"
+	ReceiverByName = "name"
+)
+
+const KindDeliveryFailure = "delivery-failure"
+
+const (
+	defaultDeliveryTimeout = 5 * time.Second
+
+	maxInflightFrames = 256
+	laneSize          = 256
+	laneIdleTimeout   = 30 * time.Second
+)
+
+type KindHandler func(ctx context.Context, msg *messaging.Message) error
+
+type DeliveryFailure struct {
+	Node      string `json:"node"`
+	MessageID string `json:"message_id"`
+	Receiver  string `json:"receiver"`
+	Reason    string `json:"reason"`
+	NotFound  bool   `json:"not_found"`
+}
+
+var messageSeq atomic.Uint64
+
+func (c *Cluster) NewMessageID() string {
+	return fmt.Sprintf("%s-%d-%d", c.config.NodeName, time.Now().UnixNano(), messageSeq.Add(1))
+}
+
+func (c *Cluster) HandleKind(kind string, handler KindHandler) {
+	c.handlersMu.Lock()
+	defer c.handlersMu.Unlock()
+	c.handlers[kind] = handler
+}
+
+func (c *Cluster) DeliveryFailures() <-chan DeliveryFailure {
+	return c.deliveryFailures
+}
+
+func (c *Cluster) Send(nodeName string, msg *messaging.Message) error {
+	if !c.running.Load() {
+		return ErrClusterNotRunning
+	}
+
+	out := c.prepare(msg)
+	if c.sequenced(nodeName, out) {
+		_, err := c.sendSequenced(nodeName, out)
+		return err
+	}
+	return c.transmit(nodeName, out)
+}
+
+func (c *Cluster) prepare(msg *messaging.Message) *messaging.Message {
+	out := *msg
+	out.Headers = make(map[string]string, len(msg.Headers)+1)
+	for k, v := range msg.Headers {
+		out.Headers[k] = v
+	}
+	out.Headers[HeaderSourceNode] = c.config.NodeName
+
+	if out.ID == "" {
+		out.ID = c.NewMessageID()
+	}
+	if out.Sender == "" {
+		out.Sender = c.config.NodeName
+	}
+	if out.Timestamp.IsZero() {
+		out.Timestamp = time.Now()
+	}
+	return &out
+}
+
+func (c *Cluster) transmit(nodeName string, msg *messaging.Message) error {
+	data, err := c.encode(nodeName, msg)
+	if err != nil {
+		return err
+	}
+
+	if nodeName == c.config.NodeName {
+		c.delegates.NotifyMsg(data)
+		return nil
+	}
+
+	return c.SendToNode(nodeName, data)
+}
+
+func (c *Cluster) SendToActor(nodeName, actorID string, msg messaging.Message) error {
+	msg.Receiver = actorID
+	msg.Headers = withHeader(msg.Headers, HeaderReceiverType, ReceiverByID)
+	return c.Send(nodeName, &msg)
+}
+
+func (c *Cluster) SendToNamedActor(nodeName, name string, msg messaging.Message) error {
+	msg.Receiver = name
+	msg.Headers = withHeader(msg.Headers, HeaderReceiverType, ReceiverByName)
+	return c.Send(nodeName, &msg)
+}
+
+// Broadcast gossips msg to every node without sequencing or acknowledgements, even with acknowledged delivery on.
+func (c *Cluster) Broadcast(msg *messaging.Message) error {
+	out := *msg
+	out.Type = messaging.Broadcast
+	out.Headers = withHeader(msg.Headers, HeaderSourceNode, c.config.NodeName)
+	if out.ID == "" {
+		out.ID = c.NewMessageID()
+	}
+	if out.Timestamp.IsZero() {
+		out.Timestamp = time.Now()
+	}
+
+	data, err := c.encode("", &out)
+	if err != nil {
+		return err
+	}
+
+	return c.BroadcastMessage(data)
+}
+
+func (c *Cluster) dispatch() {
+	for {
+		select {
+		case <-c.stopCh:
+			return
+		case frame := <-c.delegates.msgCh:
+			c.handleFrame(frame)
+		}
+	}
+}
+
+func (c *Cluster) handleFrame(frame []byte) {
+	msg, err := c.decode(frame)
+	if err != nil && (msg == nil || !errors.Is(err, ErrUndecodablePayload)) {
+		return
+	}
+
+	switch {
+	case msg.Headers[HeaderSequence] != "":
+		if !c.enqueue(inbound{msg: msg, err: err}) {
+			c.reportFailure(msg, ErrInboundQueueFull)
+		}
+	case err == nil && resolvesLocally(msg):
+		_ = c.process(msg, nil)
+	default:
+		select {
+		case c.inflight <- struct{}{}:
+		case <-c.stopCh:
+			return
+		}
+		go func() {
+			defer func() { <-c.inflight }()
+			_ = c.process(msg, err)
+		}()
+	}
+}
+
+func resolvesLocally(msg *messaging.Message) bool {
+	switch msg.Headers[HeaderKind] {
+	case KindReply, KindAck, KindDeliveryFailure:
+		return true
+	}
+	return false
+}
+
+type inbound struct {
+	msg *messaging.Message
+	err error
+}
+
+// enqueue reports whether the frame fit in its source's lane. The sender keeps
+// resending a sequenced frame until it is acknowledged, so a rejected frame is
+// delivered later and in order, or times out on the sender.
+func (c *Cluster) enqueue(item inbound) bool {
+	source := item.msg.Headers[HeaderSourceNode]
+
+	c.lanesMu.Lock()
+	defer c.lanesMu.Unlock()
+
+	lane, exists := c.lanes[source]
+	if !exists {
+		lane = make(chan inbound, laneSize)
+		c.lanes[source] = lane
+		go c.drainLane(source, lane)
+	}
+
+	select {
+	case lane <- item:
+		return true
+	default:
+		return false
+	}
+}
+
+func (c *Cluster) drainLane(source string, lane chan inbound) {
+	idle := time.NewTimer(laneIdleTimeout)
+	defer idle.Stop()
+
+	for {
+		select {
+		case <-c.stopCh:
+			return
+		case item := <-lane:
+			c.receiveSequenced(item.msg, item.err)
+			idle.Reset(laneIdleTimeout)
+		case <-idle.C:
+			c.lanesMu.Lock()
+			if len(lane) == 0 {
+				delete(c.lanes, source)
+				c.lanesMu.Unlock()
+				return
+			}
+			c.lanesMu.Unlock()
+			idle.Reset(laneIdleTimeout)
+		}
+	}
+}
+
+func (c *Cluster) dropFrame(frame []byte) {
+	msg, err := c.decode(frame)
+	if err != nil && (msg == nil || !errors.Is(err, ErrUndecodablePayload)) {
+		return
+	}
+	if resolvesLocally(msg) {
+		return
+	}
+	c.reportFailure(msg, ErrInboundQueueFull)
+}
+
+func (c *Cluster) process(msg *messaging.Message, decodeErr error) error {
+	if decodeErr != nil {
+		c.deadLetter(msg, decodeErr)
+		c.reportFailure(msg, decodeErr)
+		return decodeErr
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultDeliveryTimeout)
+	defer cancel()
+
+	if kind := msg.Headers[HeaderKind]; kind != "" {
+		c.handlersMu.RLock()
+		handler, exists := c.handlers[kind]
+		c.handlersMu.RUnlock()
+
+		if !exists {
+			err := fmt.Errorf("no handler for message kind '%s'", kind)
+			c.reportFailure(msg, err)
+			return err
+		}
+		if err := handler(ctx, msg); err != nil {
+			c.reportFailure(msg, err)
+			return err
+		}
+		return nil
+	}
+
+	if err := c.deliverLocal(ctx, msg); err != nil {
+		c.reportFailure(msg, err)
+		return err
+	}
+	return nil
+}
+
+func (c *Cluster) deliverLocal(ctx context.Context, msg *messaging.Message) error {
+	if c.system == nil {
+		return ErrInvalidSystemReference
+	}
+
+	var payload interface{} = *msg
+	if msg.Headers[HeaderRawPayload] == "true" {
+		payload = msg.Payload
+	}
+
+	if msg.Headers[HeaderReceiverType] == ReceiverByName {
+		return c.system.SendNamedMessage(ctx, msg.Receiver, payload)
+	}
+
+	return c.system.SendMessage(ctx, msg.Receiver, payload)
+}
+
+func (c *Cluster) reportFailure(msg *messaging.Message, reason error) {
+	source := msg.Headers[HeaderSourceNode]
+	if source == "" || msg.Type == messaging.Broadcast || msg.Headers[HeaderKind] == KindDeliveryFailure {
+		return
+	}
+
+	report := &messaging.Message{
+		Type:      messaging.System,
+		Receiver:  msg.Sender,
+		Timestamp: time.Now(),
+		Headers: map[string]string{
+			HeaderKind:          KindDeliveryFailure,
+			HeaderCorrelationID: msg.ID,
+		},
+		Payload: DeliveryFailure{
+			Node:      c.config.NodeName,
+			MessageID: msg.ID,
+			Receiver:  msg.Receiver,
+			Reason:    reason.Error(),
+			NotFound:  errors.Is(reason, actor.ErrActorNotFound),
+		},
+	}
+
+	_ = c.Send(source, report)
+}
+
+func (c *Cluster) handleDeliveryFailure(ctx context.Context, msg *messaging.Message) error {
+	var failure DeliveryFailure
+	if err := DecodePayload(msg, &failure); err != nil {
+		return nil
+	}
+
+	c.markRemoteRefs(failure)
+
+	c.resolvePending(failure.MessageID, &messaging.Message{
+		Type:    messaging.System,
+		Headers: map[string]string{HeaderError: failure.Reason},
+	})
+
+	select {
+	case c.deliveryFailures <- failure:
+	default:
+
+	}
+	return nil
+}
+
+func (c *Cluster) encode(nodeName string, msg *messaging.Message) ([]byte, error) {
+	frame, err := c.codecFor(nodeName).Encode(c.schemas.downcast(nodeName, msg))
+	if err != nil {
+		return nil, err
+	}
+	return c.seal(frame), nil
+}
+
+func (c *Cluster) decode(frame []byte) (*messaging.Message, error) {
+	frame, err := c.unseal(frame)
+	if err != nil {
+		return nil, err
+	}
+	return decodeFrame(frame)
+}
+
+func DecodePayload(msg *messaging.Message, v interface{}) error {
+	target := reflect.ValueOf(v)
+	if target.Kind() != reflect.Ptr || target.IsNil() {
+		return fmt.Errorf("decode target must be a non-nil pointer")
+	}
+
+	payload := reflect.ValueOf(msg.Payload)
+	if payload.IsValid() && payload.Type().AssignableTo(target.Elem().Type()) {
+		target.Elem().Set(payload)
+		return nil
+	}
+
+	var data []byte
+	switch p := msg.Payload.(type) {
+	case []byte:
+		data = p
+	case string:
+		data = []byte(p)
+	default:
+		var err error
+		data, err = json.Marshal(p)
+		if err != nil {
+			return fmt.Errorf("failed to decode payload: %w", err)
+		}
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to decode payload: %w", err)
+	}
+	return nil
+}
+
+func withHeader(headers map[string]string, key, value string) map[string]string {
+	result := make(map[string]string, len(headers)+1)
+	for k, v := range headers {
+		result[k] = v
+	}
+	result[key] = value
+	return result
+}
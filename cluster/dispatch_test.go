@@ -0,0 +1,112 @@
+package cluster
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kleeedolinux/gorilix/messaging"
+)
+
+func startDispatch(t *testing.T, c *Cluster) {
+	t.Helper()
+	c.running.Store(true)
+	go c.dispatch()
+	t.Cleanup(func() { close(c.stopCh) })
+}
+
+type pong struct {
+	Answer string `json:"answer"`
+}
+
+func TestHandlerCanRequestDuringDispatch(t *testing.T) {
+	c := NewCluster(&ClusterConfig{NodeName: "n1"}, nil)
+	c.HandleRequest("inner", func(ctx context.Context, msg *messaging.Message) (interface{}, error) {
+		return pong{Answer: "pong"}, nil
+	})
+	c.HandleRequest("outer", func(ctx context.Context, msg *messaging.Message) (interface{}, error) {
+		reply, err := c.Request(ctx, "n1", "inner", nil)
+		if err != nil {
+			return nil, err
+		}
+		var answer pong
+		err = DecodePayload(reply, &answer)
+		return answer, err
+	})
+	startDispatch(t, c)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	reply, err := c.Request(ctx, "n1", "outer", nil)
+	if err != nil {
+		t.Fatalf("Expected nested request to succeed, got %v", err)
+	}
+	var answer pong
+	if err := DecodePayload(reply, &answer); err != nil || answer.Answer != "pong" {
+		t.Errorf("Expected pong, got %+v (%v)", answer, err)
+	}
+}
+
+func TestNotifyMsgCopiesFrame(t *testing.T) {
+	d := newClusterDelegate()
+	buf := []byte("frame")
+	d.NotifyMsg(buf)
+	copy(buf, "xxxxx")
+
+	if got := <-d.msgCh; !bytes.Equal(got, []byte("frame")) {
+		t.Errorf("Expected queued frame to be unchanged, got %q", got)
+	}
+}
+
+func TestDroppedFrameIsReported(t *testing.T) {
+	c := NewCluster(&ClusterConfig{NodeName: "n1"}, nil)
+	c.running.Store(true)
+
+	frame, err := c.encode("n1", c.prepare(&messaging.Message{
+		Type:     messaging.Normal,
+		ID:       "m1",
+		Receiver: "worker",
+	}))
+	if err != nil {
+		t.Fatalf("Failed to encode frame: %v", err)
+	}
+	c.dropFrame(frame)
+
+	go c.dispatch()
+	defer close(c.stopCh)
+
+	select {
+	case failure := <-c.DeliveryFailures():
+		if failure.MessageID != "m1" || !strings.Contains(failure.Reason, ErrInboundQueueFull.Error()) {
+			t.Errorf("Unexpected failure report: %+v", failure)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected dropped frame to be reported to the sender")
+	}
+}
+
+func TestRejectedSequencedFrameIsReported(t *testing.T) {
+	c := NewCluster(&ClusterConfig{NodeName: "n1"}, nil)
+	c.lanes["n1"] = make(chan inbound)
+	startDispatch(t, c)
+
+	msg := c.prepare(&messaging.Message{Type: messaging.Normal, ID: "m1", Receiver: "worker"})
+	msg.Headers[HeaderSequence] = "1"
+	frame, err := c.encode("n1", msg)
+	if err != nil {
+		t.Fatalf("Failed to encode frame: %v", err)
+	}
+	c.handleFrame(frame)
+
+	select {
+	case failure := <-c.DeliveryFailures():
+		if failure.MessageID != "m1" || !strings.Contains(failure.Reason, ErrInboundQueueFull.Error()) {
+			t.Errorf("Unexpected failure report: %+v", failure)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the rejected sequenced frame to be reported to the sender")
+	}
+}
@@ -0,0 +1,446 @@
+package election
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kleeedolinux/gorilix/actor"
+	"github.com/kleeedolinux/gorilix/cluster"
+	"github.com/kleeedolinux/gorilix/messaging"
+)
+
+const (
+	KindAcquire    = "lease-acquire"
+	KindRelease    = "lease-release"
+	KindLeaseState = "lease-state"
+
+	checkInterval = 500 * time.Millisecond
+)
+
+var (
+	ErrNoLeader = errors.New("no leader elected")
+
+	ErrNotLeader = errors.New("node is not the leader")
+
+	ErrLeaseHeld = errors.New("lease held by another node")
+
+	ErrLeaseLost = errors.New("lease lost")
+
+	ErrInvalidTTL = errors.New("lease ttl must be positive")
+)
+
+type LeaderEvent struct {
+	Leader    string
+	Previous  string
+	Timestamp time.Time
+}
+
+type Lease struct {
+	Name    string    `json:"name"`
+	Holder  string    `json:"holder"`
+	Token   uint64    `json:"token"`
+	Expires time.Time `json:"expires"`
+}
+
+func (l *Lease) Valid() bool {
+	return time.Now().Before(l.Expires)
+}
+
+type leaseRequest struct {
+	Name   string        `json:"name"`
+	Holder string        `json:"holder"`
+	Token  uint64        `json:"token,omitempty"`
+	TTL    time.Duration `json:"ttl"`
+}
+
+type leaseReply struct {
+	Granted bool   `json:"granted"`
+	Lease   Lease  `json:"lease"`
+	Holder  string `json:"holder,omitempty"`
+}
+
+type leaseState struct {
+	Leader string           `json:"leader"`
+	Token  uint64           `json:"token"`
+	Leases map[string]Lease `json:"leases"`
+}
+
+type Election struct {
+	cluster     *cluster.Cluster
+	leader      string
+	leases      map[string]Lease
+	token       uint64
+	subscribers []actor.ActorRef
+	wake        chan struct{}
+	mu          sync.Mutex
+	subsMu      sync.RWMutex
+}
+
+func NewElection(c *cluster.Cluster) *Election {
+	e := &Election{
+		cluster: c,
+		leases:  make(map[string]Lease),
+		wake:    make(chan struct{}, 1),
+	}
+
+	c.HandleRequest(KindAcquire, e.handleAcquire)
+	c.HandleRequest(KindRelease, e.handleRelease)
+	c.HandleKind(KindLeaseState, e.handleState)
+	c.RegisterState("leases", e)
+	c.OnNodeEvent(func(cluster.NodeEvent) { e.trigger() })
+	c.OnStart(func() { go e.run() })
+
+	return e
+}
+
+// Leader is the oldest alive or suspect member; a suspect leader keeps its role until marked down.
+func (e *Election) Leader() (string, bool) {
+	var candidates []*cluster.Node
+	for _, node := range e.cluster.Members() {
+		if node.Status == cluster.NodeAlive || node.Status == cluster.NodeSuspect {
+			candidates = append(candidates, node)
+		}
+	}
+	if len(candidates) == 0 {
+		return "", false
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		ti, tj := candidates[i].StartedAt(), candidates[j].StartedAt()
+		if !ti.Equal(tj) {
+			return ti.Before(tj)
+		}
+		return candidates[i].Name < candidates[j].Name
+	})
+	return candidates[0].Name, true
+}
+
+func (e *Election) IsLeader() bool {
+	leader, ok := e.Leader()
+	return ok && leader == e.cluster.NodeName()
+}
+
+func (e *Election) Subscribe(subscriber actor.ActorRef) {
+	e.subsMu.Lock()
+	defer e.subsMu.Unlock()
+
+	for _, sub := range e.subscribers {
+		if sub.ID() == subscriber.ID() {
+			return
+		}
+	}
+	e.subscribers = append(e.subscribers, subscriber)
+}
+
+func (e *Election) Unsubscribe(subscriberID string) {
+	e.subsMu.Lock()
+	defer e.subsMu.Unlock()
+
+	var subs []actor.ActorRef
+	for _, sub := range e.subscribers {
+		if sub.ID() != subscriberID {
+			subs = append(subs, sub)
+		}
+	}
+	e.subscribers = subs
+}
+
+func (e *Election) Acquire(ctx context.Context, name string, ttl time.Duration) (*Lease, error) {
+	return e.acquire(ctx, leaseRequest{Name: name, Holder: e.cluster.NodeName(), TTL: ttl})
+}
+
+func (e *Election) Renew(ctx context.Context, lease *Lease, ttl time.Duration) (*Lease, error) {
+	return e.acquire(ctx, leaseRequest{Name: lease.Name, Holder: lease.Holder, Token: lease.Token, TTL: ttl})
+}
+
+func (e *Election) Release(ctx context.Context, lease *Lease) error {
+	request := leaseRequest{Name: lease.Name, Holder: lease.Holder, Token: lease.Token}
+
+	leader, ok := e.Leader()
+	if !ok {
+		return ErrNoLeader
+	}
+	if leader == e.cluster.NodeName() {
+		return e.release(request)
+	}
+
+	_, err := e.cluster.Request(ctx, leader, KindRelease, request)
+	return err
+}
+
+func (e *Election) Leases() []Lease {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	leases := make([]Lease, 0, len(e.leases))
+	for _, lease := range e.leases {
+		if time.Now().Before(lease.Expires) {
+			leases = append(leases, lease)
+		}
+	}
+	sort.Slice(leases, func(i, j int) bool { return leases[i].Name < leases[j].Name })
+	return leases
+}
+
+func (e *Election) acquire(ctx context.Context, request leaseRequest) (*Lease, error) {
+	if request.TTL <= 0 {
+		return nil, ErrInvalidTTL
+	}
+
+	leader, ok := e.Leader()
+	if !ok {
+		return nil, ErrNoLeader
+	}
+
+	start := time.Now()
+	var reply leaseReply
+	if leader == e.cluster.NodeName() {
+		var err error
+		if reply, err = e.grant(request); err != nil {
+			return nil, err
+		}
+	} else {
+		msg, err := e.cluster.Request(ctx, leader, KindAcquire, request)
+		if err != nil {
+			return nil, err
+		}
+		if err := cluster.DecodePayload(msg, &reply); err != nil {
+			return nil, err
+		}
+	}
+
+	if !reply.Granted {
+		if request.Token != 0 {
+			return nil, fmt.Errorf("%w: %s", ErrLeaseLost, request.Name)
+		}
+		return nil, fmt.Errorf("%w: %s held by %s", ErrLeaseHeld, request.Name, reply.Holder)
+	}
+
+	// Count the ttl from before the request so the holder gives the lease up before the leader does.
+	lease := reply.Lease
+	lease.Expires = start.Add(request.TTL)
+	return &lease, nil
+}
+
+func (e *Election) handleAcquire(ctx context.Context, msg *messaging.Message) (interface{}, error) {
+	var request leaseRequest
+	if err := cluster.DecodePayload(msg, &request); err != nil {
+		return nil, err
+	}
+	return e.grant(request)
+}
+
+func (e *Election) handleRelease(ctx context.Context, msg *messaging.Message) (interface{}, error) {
+	var request leaseRequest
+	if err := cluster.DecodePayload(msg, &request); err != nil {
+		return nil, err
+	}
+	return nil, e.release(request)
+}
+
+func (e *Election) grant(request leaseRequest) (leaseReply, error) {
+	if !e.IsLeader() {
+		return leaseReply{}, ErrNotLeader
+	}
+
+	e.mu.Lock()
+	now := time.Now()
+	current, exists := e.leases[request.Name]
+	active := exists && now.Before(current.Expires)
+
+	var reply leaseReply
+	switch {
+	case active && current.Holder != request.Holder:
+		reply.Holder = current.Holder
+	case request.Token != 0 && (!active || current.Token != request.Token):
+	case active:
+		current.Expires = now.Add(request.TTL)
+		reply = leaseReply{Granted: true, Lease: current}
+	default:
+		current = Lease{
+			Name:    request.Name,
+			Holder:  request.Holder,
+			Token:   e.nextToken(),
+			Expires: now.Add(request.TTL),
+		}
+		reply = leaseReply{Granted: true, Lease: current}
+	}
+
+	if reply.Granted {
+		e.leases[request.Name] = current
+	}
+	state := e.localState()
+	e.mu.Unlock()
+
+	if reply.Granted {
+		e.replicate(state)
+	}
+	return reply, nil
+}
+
+func (e *Election) release(request leaseRequest) error {
+	if !e.IsLeader() {
+		return ErrNotLeader
+	}
+
+	e.mu.Lock()
+	current, exists := e.leases[request.Name]
+	if !exists || current.Holder != request.Holder || current.Token != request.Token {
+		e.mu.Unlock()
+		return nil
+	}
+	delete(e.leases, request.Name)
+	state := e.localState()
+	e.mu.Unlock()
+
+	e.replicate(state)
+	return nil
+}
+
+// nextToken puts the leader's start time in the high bits, so a younger leader's tokens are higher.
+func (e *Election) nextToken() uint64 {
+	if self, exists := e.cluster.GetNode(e.cluster.NodeName()); exists {
+		if base := uint64(self.StartedAt().Unix()) << 32; e.token < base {
+			e.token = base
+		}
+	}
+	e.token++
+	return e.token
+}
+
+func (e *Election) localState() leaseState {
+	state := leaseState{
+		Leader: e.cluster.NodeName(),
+		Token:  e.token,
+		Leases: make(map[string]Lease, len(e.leases)),
+	}
+	now := time.Now()
+	for name, lease := range e.leases {
+		if now.Before(lease.Expires) {
+			state.Leases[name] = lease
+		}
+	}
+	return state
+}
+
+func (e *Election) replicate(state leaseState) {
+	for _, node := range e.cluster.Members() {
+		if node.Name != e.cluster.NodeName() && node.Status == cluster.NodeAlive {
+			_ = e.cluster.Send(node.Name, &messaging.Message{
+				Type:    messaging.System,
+				Payload: state,
+				Headers: map[string]string{cluster.HeaderKind: KindLeaseState},
+			})
+		}
+	}
+}
+
+func (e *Election) LocalState() []byte {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if !e.IsLeader() {
+		return nil
+	}
+
+	data, err := json.Marshal(e.localState())
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+func (e *Election) MergeRemoteState(data []byte) {
+	var state leaseState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return
+	}
+	e.merge(state)
+}
+
+func (e *Election) handleState(ctx context.Context, msg *messaging.Message) error {
+	var state leaseState
+	if err := cluster.DecodePayload(msg, &state); err != nil {
+		return err
+	}
+	e.merge(state)
+	return nil
+}
+
+func (e *Election) merge(state leaseState) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if state.Token > e.token {
+		e.token = state.Token
+	}
+
+	now := time.Now()
+	for name, lease := range state.Leases {
+		current, exists := e.leases[name]
+		if !exists || lease.Token > current.Token || lease.Token == current.Token && lease.Expires.After(current.Expires) {
+			e.leases[name] = lease
+		}
+	}
+
+	leader, ok := e.Leader()
+	authoritative := ok && leader == state.Leader && leader != e.cluster.NodeName()
+	for name, lease := range e.leases {
+		_, kept := state.Leases[name]
+		if !now.Before(lease.Expires) || authoritative && !kept && lease.Token <= state.Token {
+			delete(e.leases, name)
+		}
+	}
+}
+
+func (e *Election) run() {
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-e.cluster.Done():
+			return
+		case <-ticker.C:
+		case <-e.wake:
+		}
+		e.check()
+	}
+}
+
+func (e *Election) trigger() {
+	select {
+	case e.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (e *Election) check() {
+	leader, _ := e.Leader()
+
+	e.mu.Lock()
+	previous := e.leader
+	e.leader = leader
+	e.mu.Unlock()
+
+	if leader == previous {
+		return
+	}
+
+	event := &LeaderEvent{Leader: leader, Previous: previous, Timestamp: time.Now()}
+
+	e.subsMu.RLock()
+	subscribers := append([]actor.ActorRef(nil), e.subscribers...)
+	e.subsMu.RUnlock()
+
+	for _, sub := range subscribers {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		_ = sub.Send(ctx, event)
+		cancel()
+	}
+}
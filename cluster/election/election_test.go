@@ -0,0 +1,131 @@
+package election
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kleeedolinux/gorilix/cluster"
+	"github.com/kleeedolinux/gorilix/cluster/internal/clustertest"
+)
+
+func newNode(t *testing.T, name string) (*cluster.Cluster, *Election) {
+	t.Helper()
+
+	c := cluster.NewCluster(clustertest.Config(name), nil)
+	e := NewElection(c)
+	clustertest.Start(t, c)
+	return c, e
+}
+
+func TestLeasesThroughLeader(t *testing.T) {
+	c1, e1 := newNode(t, "n1")
+	c2, e2 := newNode(t, "n2")
+	clustertest.Join(t, c2, c1)
+
+	clustertest.WaitFor(t, "both nodes to agree on n1", func() bool {
+		l1, _ := e1.Leader()
+		l2, _ := e2.Leader()
+		return l1 == "n1" && l2 == "n1"
+	})
+	if !e1.IsLeader() || e2.IsLeader() {
+		t.Fatal("Expected only the oldest node to be leader")
+	}
+
+	ctx := context.Background()
+	if _, err := e2.Acquire(ctx, "report", 0); !errors.Is(err, ErrInvalidTTL) {
+		t.Errorf("Expected ErrInvalidTTL, got %v", err)
+	}
+
+	lease, err := e2.Acquire(ctx, "report", time.Minute)
+	if err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+	if lease.Holder != "n2" || lease.Token == 0 || !lease.Valid() {
+		t.Fatalf("Unexpected lease %+v", lease)
+	}
+
+	if _, err := e1.Acquire(ctx, "report", time.Minute); !errors.Is(err, ErrLeaseHeld) {
+		t.Errorf("Expected ErrLeaseHeld, got %v", err)
+	}
+
+	renewed, err := e2.Renew(ctx, lease, time.Minute)
+	if err != nil {
+		t.Fatalf("Renew failed: %v", err)
+	}
+	if renewed.Token != lease.Token {
+		t.Errorf("Expected renewal to keep token %d, got %d", lease.Token, renewed.Token)
+	}
+
+	if err := e2.Release(ctx, renewed); err != nil {
+		t.Fatalf("Release failed: %v", err)
+	}
+	if _, err := e2.Renew(ctx, renewed, time.Minute); !errors.Is(err, ErrLeaseLost) {
+		t.Errorf("Expected ErrLeaseLost after release, got %v", err)
+	}
+
+	next, err := e1.Acquire(ctx, "report", time.Minute)
+	if err != nil {
+		t.Fatalf("Acquire after release failed: %v", err)
+	}
+	if next.Token <= lease.Token {
+		t.Errorf("Expected token above %d, got %d", lease.Token, next.Token)
+	}
+}
+
+func TestFencingTokensIncreaseAcrossFailover(t *testing.T) {
+	c1, e1 := newNode(t, "n1")
+	c2, e2 := newNode(t, "n2")
+	clustertest.Join(t, c2, c1)
+
+	clustertest.WaitFor(t, "n2 to follow n1", func() bool {
+		leader, _ := e2.Leader()
+		return leader == "n1"
+	})
+
+	ctx := context.Background()
+	lease, err := e1.Acquire(ctx, "report", time.Minute)
+	if err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+	clustertest.WaitFor(t, "the lease to replicate", func() bool { return len(e2.Leases()) == 1 })
+
+	if err := c1.Leave(5 * time.Second); err != nil {
+		t.Fatalf("Leave failed: %v", err)
+	}
+	clustertest.WaitFor(t, "n2 to take over", e2.IsLeader)
+
+	if _, err := e2.Acquire(ctx, "report", time.Minute); !errors.Is(err, ErrLeaseHeld) {
+		t.Errorf("Expected the new leader to honor the running lease, got %v", err)
+	}
+
+	next, err := e2.Acquire(ctx, "batch", time.Minute)
+	if err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+	if next.Token <= lease.Token {
+		t.Errorf("Expected token above %d after failover, got %d", lease.Token, next.Token)
+	}
+}
+
+func TestMergeKeepsLeasesFromPreviousLeader(t *testing.T) {
+	e := NewElection(cluster.NewCluster(&cluster.ClusterConfig{NodeName: "n2"}, nil))
+
+	expires := time.Now().Add(time.Minute)
+	e.merge(leaseState{Leader: "n1", Token: 5, Leases: map[string]Lease{
+		"report": {Name: "report", Holder: "n3", Token: 5, Expires: expires},
+	}})
+	e.merge(leaseState{Leader: "n0", Token: 3, Leases: map[string]Lease{
+		"report": {Name: "report", Holder: "n4", Token: 3, Expires: expires},
+		"batch":  {Name: "batch", Holder: "n4", Token: 2, Expires: time.Now().Add(-time.Second)},
+	}})
+
+	leases := e.Leases()
+	if len(leases) != 1 || leases[0].Holder != "n3" || leases[0].Token != 5 {
+		t.Errorf("Expected the newest report lease only, got %+v", leases)
+	}
+	if e.token != 5 {
+		t.Errorf("Expected token 5, got %d", e.token)
+	}
+}
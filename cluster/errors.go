@@ -14,4 +14,32 @@ var (
 
 	
 	ErrNodeNotFound = errors.New("node not found")
+
+	ErrUnknownCodec = errors.New("unknown codec")
+
+	ErrUnsupportedWireVersion = errors.New("unsupported wire version")
+
+	ErrUndecodablePayload = errors.New("payload cannot be decoded")
+
+	ErrEncryptionNotEnabled = errors.New("gossip encryption not enabled")
+
+	ErrAdmissionDenied = errors.New("node admission denied")
+
+	ErrAdmissionWithoutEncryption = errors.New("admission secret requires gossip encryption")
+
+	ErrUnauthenticatedFrame = errors.New("unauthenticated frame")
+
+	ErrReplayedFrame = errors.New("replayed or expired frame")
+
+	ErrReservedMetaKey = errors.New("node metadata key is reserved")
+
+	ErrMetaTooLarge = errors.New("node metadata too large")
+
+	ErrInvalidSplitBrainConfig = errors.New("invalid split brain resolver config")
+
+	ErrDeliveryTimeout = errors.New("delivery not acknowledged")
+
+	ErrInboundQueueFull = errors.New("inbound message queue full")
+
+	ErrBroadcastNotAcknowledged = errors.New("broadcasts are not acknowledged")
 )
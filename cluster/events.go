@@ -0,0 +1,57 @@
+package cluster
+
+import (
+	"context"
+
+	"github.com/kleeedolinux/gorilix/actor"
+)
+
+type NodeEventType int
+
+const (
+	NodeUp NodeEventType = iota
+	NodeDown
+)
+
+type NodeEvent struct {
+	Type NodeEventType
+	Node string
+}
+
+type NodeListener func(event NodeEvent)
+
+func (c *Cluster) OnNodeEvent(listener NodeListener) {
+	c.listenersMu.Lock()
+	defer c.listenersMu.Unlock()
+	c.listeners = append(c.listeners, listener)
+}
+
+func (c *Cluster) Done() <-chan struct{} {
+	return c.stopCh
+}
+
+func (c *Cluster) emitNodeEvent(event NodeEvent) {
+	if event.Node == c.config.NodeName {
+		return
+	}
+
+	if c.system != nil {
+		c.enqueueNotification(func() {
+			ctx, cancel := context.WithTimeout(context.Background(), defaultDeliveryTimeout)
+			defer cancel()
+			if event.Type == NodeUp {
+				c.system.NotifyNodeUp(ctx, event.Node)
+			} else {
+				c.system.NotifyNodeDown(ctx, event.Node, actor.ErrNoConnection)
+			}
+		})
+	}
+
+	c.listenersMu.RLock()
+	listeners := append([]NodeListener(nil), c.listeners...)
+	c.listenersMu.RUnlock()
+
+	for _, listener := range listeners {
+		listener(event)
+	}
+}
@@ -0,0 +1,381 @@
+package global
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kleeedolinux/gorilix/actor"
+	"github.com/kleeedolinux/gorilix/cluster"
+	"github.com/kleeedolinux/gorilix/messaging"
+)
+
+const (
+	KindClaim   = "global-claim"
+	KindRelease = "global-release"
+
+	stateName     = "global"
+	sweepInterval = time.Second
+)
+
+var (
+	ErrNameAlreadyRegistered = errors.New("global name already registered")
+
+	ErrNameNotRegistered = errors.New("global name not registered")
+
+	ErrNotLocalActor = errors.New("global names can only be registered for local actors")
+)
+
+type Entry struct {
+	Name       string `json:"name"`
+	Node       string `json:"node"`
+	ActorID    string `json:"actor_id"`
+	Registered int64  `json:"registered"`
+}
+
+func (e Entry) Address() actor.Address {
+	return actor.Address{Node: e.Node, ID: e.ActorID}
+}
+
+func (e Entry) sameOwner(other Entry) bool {
+	return e.Node == other.Node && e.ActorID == other.ActorID
+}
+
+type ConflictResolver func(name string, existing, incoming Entry) Entry
+
+type NameConflict struct {
+	Name   string
+	Winner actor.Address
+}
+
+func KeepOldest(name string, existing, incoming Entry) Entry {
+	if incoming.Registered < existing.Registered ||
+		incoming.Registered == existing.Registered && incoming.Node < existing.Node {
+		return incoming
+	}
+	return existing
+}
+
+func KeepNewest(name string, existing, incoming Entry) Entry {
+	if incoming.Registered > existing.Registered ||
+		incoming.Registered == existing.Registered && incoming.Node < existing.Node {
+		return incoming
+	}
+	return existing
+}
+
+type nodeState struct {
+	Node    string  `json:"node"`
+	Entries []Entry `json:"entries"`
+}
+
+type Registry struct {
+	cluster  *cluster.Cluster
+	resolver ConflictResolver
+	entries  map[string]Entry
+	local    map[string]actor.ActorRef
+	mu       sync.RWMutex
+}
+
+func NewRegistry(c *cluster.Cluster, resolver ConflictResolver) *Registry {
+	if resolver == nil {
+		resolver = KeepOldest
+	}
+
+	r := &Registry{
+		cluster:  c,
+		resolver: resolver,
+		entries:  make(map[string]Entry),
+		local:    make(map[string]actor.ActorRef),
+	}
+
+	c.HandleRequest(KindClaim, r.handleClaim)
+	c.HandleKind(KindRelease, r.handleRelease)
+	c.RegisterState(stateName, r)
+	c.OnNodeEvent(r.handleNodeEvent)
+	c.OnStart(func() { go r.sweep() })
+
+	return r
+}
+
+func (r *Registry) Register(ctx context.Context, name string, ref actor.ActorRef) error {
+	self := r.cluster.NodeName()
+
+	if address, err := actor.ParseAddress(ref.ID()); err == nil && address.Node != self {
+		return ErrNotLocalActor
+	}
+
+	entry := Entry{
+		Name:       name,
+		Node:       self,
+		ActorID:    ref.ID(),
+		Registered: time.Now().UnixNano(),
+	}
+
+	r.mu.Lock()
+	if existing, exists := r.entries[name]; exists && r.isAlive(existing) {
+		r.mu.Unlock()
+		return fmt.Errorf("%w: %s", ErrNameAlreadyRegistered, name)
+	}
+	r.entries[name] = entry
+	r.local[name] = ref
+	r.mu.Unlock()
+
+	var claimed []string
+	for _, node := range r.peers() {
+		if _, err := r.cluster.Request(ctx, node, KindClaim, entry); err != nil {
+			r.release(entry, claimed)
+			return fmt.Errorf("failed to register global name %s: %w", name, err)
+		}
+		claimed = append(claimed, node)
+	}
+
+	return nil
+}
+
+func (r *Registry) Unregister(name string) error {
+	r.mu.RLock()
+	entry, exists := r.entries[name]
+	r.mu.RUnlock()
+
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrNameNotRegistered, name)
+	}
+
+	r.release(entry, r.peers())
+	return nil
+}
+
+func (r *Registry) WhereIs(name string) (actor.ActorRef, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entry, exists := r.entries[name]
+	if !exists {
+		return nil, false
+	}
+
+	if ref, local := r.local[name]; local {
+		return ref, true
+	}
+	return r.cluster.RemoteRef(entry.Address()), true
+}
+
+func (r *Registry) Lookup(name string) (Entry, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entry, exists := r.entries[name]
+	return entry, exists
+}
+
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.entries))
+	for name := range r.entries {
+		names = append(names, name)
+	}
+	return names
+}
+
+func (r *Registry) LocalState() []byte {
+	self := r.cluster.NodeName()
+
+	r.mu.RLock()
+	state := nodeState{Node: self}
+	for _, entry := range r.entries {
+		if entry.Node == self {
+			state.Entries = append(state.Entries, entry)
+		}
+	}
+	r.mu.RUnlock()
+
+	data, _ := json.Marshal(state)
+	return data
+}
+
+func (r *Registry) MergeRemoteState(data []byte) {
+	var state nodeState
+	if err := json.Unmarshal(data, &state); err != nil || state.Node == r.cluster.NodeName() {
+		return
+	}
+
+	claimed := make(map[string]Entry, len(state.Entries))
+	for _, entry := range state.Entries {
+		claimed[entry.Name] = entry
+	}
+
+	var losers []loser
+
+	r.mu.Lock()
+	for name, entry := range r.entries {
+		if entry.Node != state.Node {
+			continue
+		}
+		if current, exists := claimed[name]; !exists || !current.sameOwner(entry) {
+			delete(r.entries, name)
+		}
+	}
+
+	for name, incoming := range claimed {
+		existing, exists := r.entries[name]
+		if !exists || existing.sameOwner(incoming) {
+			r.entries[name] = incoming
+			continue
+		}
+
+		winner := r.resolver(name, existing, incoming)
+		if winner.sameOwner(existing) {
+			continue
+		}
+
+		r.entries[name] = winner
+		if ref, local := r.local[name]; local {
+			delete(r.local, name)
+			losers = append(losers, loser{ref: ref, conflict: NameConflict{Name: name, Winner: winner.Address()}})
+		}
+	}
+	r.mu.Unlock()
+
+	if len(losers) > 0 {
+		go notifyLosers(losers)
+	}
+}
+
+type loser struct {
+	ref      actor.ActorRef
+	conflict NameConflict
+}
+
+func notifyLosers(losers []loser) {
+	for _, l := range losers {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		_ = l.ref.Send(ctx, &l.conflict)
+		cancel()
+	}
+}
+
+func (r *Registry) handleClaim(ctx context.Context, msg *messaging.Message) (interface{}, error) {
+	var entry Entry
+	if err := cluster.DecodePayload(msg, &entry); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, exists := r.entries[entry.Name]; exists && !existing.sameOwner(entry) && r.isAlive(existing) {
+		return nil, fmt.Errorf("%w: %s", ErrNameAlreadyRegistered, entry.Name)
+	}
+
+	r.entries[entry.Name] = entry
+	if entry.Node != r.cluster.NodeName() {
+		delete(r.local, entry.Name)
+	}
+	return nil, nil
+}
+
+func (r *Registry) handleRelease(ctx context.Context, msg *messaging.Message) error {
+	var entry Entry
+	if err := cluster.DecodePayload(msg, &entry); err != nil {
+		return nil
+	}
+
+	r.remove(entry)
+	return nil
+}
+
+func (r *Registry) handleNodeEvent(event cluster.NodeEvent) {
+	if event.Type != cluster.NodeDown {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for name, entry := range r.entries {
+		if entry.Node == event.Node {
+			delete(r.entries, name)
+		}
+	}
+}
+
+func (r *Registry) release(entry Entry, nodes []string) {
+	r.remove(entry)
+
+	for _, node := range nodes {
+		_ = r.cluster.Send(node, &messaging.Message{
+			Type:    messaging.System,
+			Payload: entry,
+			Headers: map[string]string{cluster.HeaderKind: KindRelease},
+		})
+	}
+}
+
+func (r *Registry) remove(entry Entry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if current, exists := r.entries[entry.Name]; exists && current.sameOwner(entry) {
+		delete(r.entries, entry.Name)
+		if entry.Node == r.cluster.NodeName() {
+			delete(r.local, entry.Name)
+		}
+	}
+}
+
+func (r *Registry) sweep() {
+	ticker := time.NewTicker(sweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.cluster.Done():
+			return
+		case <-ticker.C:
+			r.releaseDead()
+		}
+	}
+}
+
+func (r *Registry) releaseDead() {
+	r.mu.RLock()
+	var dead []Entry
+	for name, ref := range r.local {
+		if !ref.IsRunning() {
+			dead = append(dead, r.entries[name])
+		}
+	}
+	r.mu.RUnlock()
+
+	for _, entry := range dead {
+		r.release(entry, r.peers())
+	}
+}
+
+func (r *Registry) peers() []string {
+	self := r.cluster.NodeName()
+
+	var nodes []string
+	for _, node := range r.cluster.Members() {
+		if node.Name != self && node.Status == cluster.NodeAlive {
+			nodes = append(nodes, node.Name)
+		}
+	}
+	return nodes
+}
+
+func (r *Registry) isAlive(entry Entry) bool {
+	if entry.Node == r.cluster.NodeName() {
+		ref, exists := r.local[entry.Name]
+		return exists && ref.IsRunning()
+	}
+
+	node, exists := r.cluster.GetNode(entry.Node)
+	return exists && node.Status == cluster.NodeAlive
+}
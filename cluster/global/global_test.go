@@ -0,0 +1,194 @@
+package global
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kleeedolinux/gorilix/cluster"
+	"github.com/kleeedolinux/gorilix/cluster/internal/clustertest"
+	"github.com/kleeedolinux/gorilix/messaging"
+)
+
+func newTestRegistry(resolver ConflictResolver) *Registry {
+	return NewRegistry(cluster.NewCluster(&cluster.ClusterConfig{NodeName: "n1"}, nil), resolver)
+}
+
+func remoteState(node string, entries ...Entry) []byte {
+	r := &Registry{cluster: cluster.NewCluster(&cluster.ClusterConfig{NodeName: node}, nil), entries: make(map[string]Entry)}
+	for _, entry := range entries {
+		r.entries[entry.Name] = entry
+	}
+	return r.LocalState()
+}
+
+func TestConflictResolvers(t *testing.T) {
+	older := Entry{Name: "svc", Node: "n2", ActorID: "a", Registered: 1}
+	newer := Entry{Name: "svc", Node: "n1", ActorID: "b", Registered: 2}
+	tiedLow := Entry{Name: "svc", Node: "n1", ActorID: "c", Registered: 3}
+	tiedHigh := Entry{Name: "svc", Node: "n2", ActorID: "d", Registered: 3}
+
+	tests := []struct {
+		name     string
+		resolver ConflictResolver
+		existing Entry
+		incoming Entry
+		want     Entry
+	}{
+		{"oldest keeps existing", KeepOldest, older, newer, older},
+		{"oldest takes incoming", KeepOldest, newer, older, older},
+		{"oldest tie by node", KeepOldest, tiedHigh, tiedLow, tiedLow},
+		{"newest keeps existing", KeepNewest, newer, older, newer},
+		{"newest takes incoming", KeepNewest, older, newer, newer},
+		{"newest tie by node", KeepNewest, tiedLow, tiedHigh, tiedLow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.resolver("svc", tt.existing, tt.incoming); got != tt.want {
+				t.Errorf("Expected %+v, got %+v", tt.want, got)
+			}
+			if got := tt.resolver("svc", tt.incoming, tt.existing); got != tt.want {
+				t.Errorf("Expected resolution to be symmetric, got %+v", got)
+			}
+		})
+	}
+}
+
+func TestConcurrentRegisterHasOneWinner(t *testing.T) {
+	r := newTestRegistry(nil)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs <- r.Register(context.Background(), "svc", clustertest.NewRecorder(string(rune('a'+i))))
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	wins := 0
+	for err := range errs {
+		switch {
+		case err == nil:
+			wins++
+		case !errors.Is(err, ErrNameAlreadyRegistered):
+			t.Errorf("Unexpected error: %v", err)
+		}
+	}
+	if wins != 1 {
+		t.Errorf("Expected exactly one registration to win, got %d", wins)
+	}
+}
+
+func TestClaimRejectedWhileLocalOwnerAlive(t *testing.T) {
+	r := newTestRegistry(nil)
+	if err := r.Register(context.Background(), "svc", clustertest.NewRecorder("worker")); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	claim := &messaging.Message{Payload: Entry{Name: "svc", Node: "n2", ActorID: "other", Registered: 1}}
+	if _, err := r.handleClaim(context.Background(), claim); !errors.Is(err, ErrNameAlreadyRegistered) {
+		t.Errorf("Expected claim to be rejected, got %v", err)
+	}
+
+	claim = &messaging.Message{Payload: Entry{Name: "free", Node: "n2", ActorID: "other", Registered: 1}}
+	if _, err := r.handleClaim(context.Background(), claim); err != nil {
+		t.Errorf("Expected claim on a free name to succeed, got %v", err)
+	}
+	if entry, _ := r.Lookup("free"); entry.Node != "n2" {
+		t.Errorf("Expected free to be held by n2, got %+v", entry)
+	}
+}
+
+func TestClaimReplacesDeadLocalOwner(t *testing.T) {
+	r := newTestRegistry(nil)
+	local := clustertest.NewRecorder("worker")
+	if err := r.Register(context.Background(), "svc", local); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	local.Stop()
+
+	claim := &messaging.Message{Payload: Entry{Name: "svc", Node: "n2", ActorID: "other", Registered: 2}}
+	if _, err := r.handleClaim(context.Background(), claim); err != nil {
+		t.Fatalf("Expected claim over a dead local owner to succeed, got %v", err)
+	}
+	if ref, _ := r.WhereIs("svc"); ref == nil || ref.ID() != "other@n2" {
+		t.Errorf("Expected WhereIs to return the new remote owner, got %v", ref)
+	}
+
+	r.releaseDead()
+	if entry, exists := r.Lookup("svc"); !exists || entry.Node != "n2" {
+		t.Errorf("Expected svc to stay with n2 after a sweep, got %+v", entry)
+	}
+}
+
+func TestMergeResolvesConflicts(t *testing.T) {
+	tests := []struct {
+		name     string
+		resolver ConflictResolver
+		remoteAt int64
+		winner   string
+	}{
+		{"keep oldest loses to older remote", KeepOldest, 1, "n2"},
+		{"keep newest keeps local", KeepNewest, 1, "n1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRegistry(tt.resolver)
+			local := clustertest.NewRecorder("worker")
+			if err := r.Register(context.Background(), "svc", local); err != nil {
+				t.Fatalf("Register failed: %v", err)
+			}
+
+			r.MergeRemoteState(remoteState("n2", Entry{Name: "svc", Node: "n2", ActorID: "other", Registered: tt.remoteAt}))
+
+			entry, _ := r.Lookup("svc")
+			if entry.Node != tt.winner {
+				t.Fatalf("Expected %s to hold svc, got %+v", tt.winner, entry)
+			}
+
+			if tt.winner == "n1" {
+				return
+			}
+			if ref, _ := r.WhereIs("svc"); ref.ID() != "other@n2" {
+				t.Errorf("Expected WhereIs to return the remote winner, got %s", ref.ID())
+			}
+			select {
+			case msg := <-local.Messages:
+				conflict, ok := msg.(*NameConflict)
+				if !ok || conflict.Name != "svc" || conflict.Winner.String() != "other@n2" {
+					t.Errorf("Unexpected conflict notification %#v", msg)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("Expected the losing actor to be notified")
+			}
+		})
+	}
+}
+
+func TestNodeDownRemovesEntries(t *testing.T) {
+	r := newTestRegistry(nil)
+	if err := r.Register(context.Background(), "local", clustertest.NewRecorder("worker")); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	r.MergeRemoteState(remoteState("n2",
+		Entry{Name: "a", Node: "n2", ActorID: "x", Registered: 1},
+		Entry{Name: "b", Node: "n2", ActorID: "y", Registered: 1},
+	))
+	r.MergeRemoteState(remoteState("n3", Entry{Name: "c", Node: "n3", ActorID: "z", Registered: 1}))
+
+	r.handleNodeEvent(cluster.NodeEvent{Type: cluster.NodeDown, Node: "n2"})
+
+	for name, want := range map[string]bool{"local": true, "a": false, "b": false, "c": true} {
+		if _, exists := r.Lookup(name); exists != want {
+			t.Errorf("Expected %s registered=%v after n2 went down", name, want)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package clustertest
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kleeedolinux/gorilix/cluster"
+)
+
+const waitTimeout = 5 * time.Second
+
+func Config(name string) *cluster.ClusterConfig {
+	config := cluster.DefaultConfig()
+	config.NodeName = name
+	config.BindAddr = "127.0.0.1"
+	config.BindPort = 0
+	return config
+}
+
+func Start(t testing.TB, c *cluster.Cluster) {
+	t.Helper()
+
+	if err := c.Start(); err != nil {
+		t.Fatalf("Failed to start %s: %v", c.NodeName(), err)
+	}
+	t.Cleanup(func() { _ = c.Stop() })
+}
+
+func Join(t testing.TB, c, seed *cluster.Cluster) {
+	t.Helper()
+
+	self := seed.Self()
+	if _, err := c.Join([]string{fmt.Sprintf("%s:%d", self.Addr, self.Port)}); err != nil {
+		t.Fatalf("Join failed: %v", err)
+	}
+}
+
+func WaitFor(t testing.TB, what string, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(waitTimeout)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for %s", what)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func Alive(c *cluster.Cluster) int {
+	count := 0
+	for _, node := range c.Members() {
+		if node.Status == cluster.NodeAlive {
+			count++
+		}
+	}
+	return count
+}
+
+type Recorder struct {
+	id       string
+	Messages chan interface{}
+	stopped  atomic.Bool
+}
+
+func NewRecorder(id string) *Recorder {
+	return &Recorder{id: id, Messages: make(chan interface{}, 100)}
+}
+
+func (r *Recorder) Send(ctx context.Context, message interface{}) error {
+	r.Messages <- message
+	return nil
+}
+
+func (r *Recorder) ID() string {
+	return r.id
+}
+
+func (r *Recorder) IsRunning() bool {
+	return !r.stopped.Load()
+}
+
+func (r *Recorder) Stop() {
+	r.stopped.Store(true)
+}
+
+func (r *Recorder) Expect(t testing.TB, want string) {
+	t.Helper()
+
+	select {
+	case got := <-r.Messages:
+		if s := fmt.Sprintf("%s", got); s != want {
+			t.Errorf("Expected %q, got %q", want, s)
+		}
+	case <-time.After(waitTimeout):
+		t.Fatalf("Expected %q to arrive", want)
+	}
+}
@@ -0,0 +1,355 @@
+package cluster
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/memberlist"
+	"github.com/kleeedolinux/gorilix/actor"
+	"github.com/kleeedolinux/gorilix/messaging"
+)
+
+const (
+	MetaIncarnation = "gorilix-incarnation"
+	KindLeaving     = "node-leaving"
+
+	defaultDownAfter       = 10 * time.Second
+	lifecycleCheckInterval = 500 * time.Millisecond
+)
+
+type MemberEvent struct {
+	Node      string
+	Status    NodeStatus
+	Previous  NodeStatus
+	Timestamp time.Time
+}
+
+func (s NodeStatus) String() string {
+	switch s {
+	case NodeJoining:
+		return "joining"
+	case NodeAlive:
+		return "up"
+	case NodeSuspect:
+		return "suspect"
+	case NodeLeaving:
+		return "leaving"
+	case NodeDead:
+		return "down"
+	case NodeRemoved:
+		return "removed"
+	}
+	return "unknown"
+}
+
+func (c *Cluster) SubscribeMembers(subscriber actor.ActorRef) {
+	c.memberSubsMu.Lock()
+	defer c.memberSubsMu.Unlock()
+
+	for _, sub := range c.memberSubs {
+		if sub.ID() == subscriber.ID() {
+			return
+		}
+	}
+	c.memberSubs = append(c.memberSubs, subscriber)
+}
+
+func (c *Cluster) UnsubscribeMembers(subscriberID string) {
+	c.memberSubsMu.Lock()
+	defer c.memberSubsMu.Unlock()
+
+	var subs []actor.ActorRef
+	for _, sub := range c.memberSubs {
+		if sub.ID() != subscriberID {
+			subs = append(subs, sub)
+		}
+	}
+	c.memberSubs = subs
+}
+
+func (c *Cluster) OnStart(hook func()) {
+	c.listenersMu.Lock()
+	if !c.running.Load() {
+		c.startHooks = append(c.startHooks, hook)
+		c.listenersMu.Unlock()
+		return
+	}
+	c.listenersMu.Unlock()
+
+	hook()
+}
+
+func (c *Cluster) OnLeave(hook func()) {
+	c.listenersMu.Lock()
+	defer c.listenersMu.Unlock()
+	c.leaveHooks = append(c.leaveHooks, hook)
+}
+
+func (n *Node) StartedAt() time.Time {
+	nanos, err := strconv.ParseInt(n.Meta[MetaIncarnation], 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(0, nanos)
+}
+
+type lifecycleChange struct {
+	events []MemberEvent
+	up     []string
+	down   []string
+}
+
+func (l *lifecycleChange) move(node *Node, status NodeStatus) {
+	l.events = append(l.events, MemberEvent{
+		Node:      node.Name,
+		Status:    status,
+		Previous:  node.Status,
+		Timestamp: time.Now(),
+	})
+	node.Status = status
+}
+
+func (c *Cluster) nodeJoined(member *memberlist.Node) {
+	var change lifecycleChange
+	meta := decodeNodeMeta(member.Meta)
+
+	c.nodesMutex.Lock()
+	node, exists := c.nodes[member.Name]
+	if exists && node.Meta[MetaIncarnation] != meta[MetaIncarnation] {
+		c.markDown(node, &change)
+		exists = false
+	}
+
+	if exists {
+		node.Addr = member.Addr
+		node.Port = member.Port
+		node.Meta = meta
+		delete(c.unreachable, member.Name)
+		if node.Status != NodeAlive {
+			change.move(node, NodeAlive)
+		}
+	} else {
+		node = &Node{
+			Name:   member.Name,
+			Addr:   member.Addr,
+			Port:   member.Port,
+			Meta:   meta,
+			Status: NodeRemoved,
+		}
+		c.nodes[member.Name] = node
+		change.move(node, NodeJoining)
+		change.move(node, NodeAlive)
+		change.up = append(change.up, member.Name)
+	}
+	c.nodesMutex.Unlock()
+
+	c.applyLifecycle(change)
+}
+
+func (c *Cluster) nodeLeft(member *memberlist.Node) {
+	var change lifecycleChange
+
+	c.nodesMutex.Lock()
+	if node, exists := c.nodes[member.Name]; exists {
+		if node.Status == NodeLeaving {
+			c.markDown(node, &change)
+		} else {
+			c.markUnreachable(node, &change)
+		}
+	}
+	c.nodesMutex.Unlock()
+
+	c.applyLifecycle(change)
+}
+
+func (c *Cluster) nodeUpdated(member *memberlist.Node) {
+	c.nodesMutex.Lock()
+	defer c.nodesMutex.Unlock()
+
+	if node, exists := c.nodes[member.Name]; exists {
+		node.Addr = member.Addr
+		node.Port = member.Port
+		node.Meta = decodeNodeMeta(member.Meta)
+	}
+}
+
+func (c *Cluster) markUnreachable(node *Node, change *lifecycleChange) {
+	if _, exists := c.unreachable[node.Name]; !exists {
+		c.unreachable[node.Name] = time.Now()
+	}
+	if node.Status == NodeAlive || node.Status == NodeJoining {
+		change.move(node, NodeSuspect)
+	}
+}
+
+func (c *Cluster) markDown(node *Node, change *lifecycleChange) {
+	wasUp := node.Status != NodeJoining
+	change.move(node, NodeDead)
+	change.move(node, NodeRemoved)
+	delete(c.nodes, node.Name)
+	delete(c.unreachable, node.Name)
+	if wasUp {
+		change.down = append(change.down, node.Name)
+	}
+}
+
+func (c *Cluster) watchMembers() {
+	ticker := time.NewTicker(lifecycleCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.stopCh:
+			return
+		case <-ticker.C:
+			c.checkMembers()
+		}
+	}
+}
+
+func (c *Cluster) checkMembers() {
+	if c.config.SplitBrain != nil {
+		c.resolveSplitBrain()
+		return
+	}
+
+	var change lifecycleChange
+
+	c.nodesMutex.Lock()
+	for name, node := range c.nodes {
+		if node.Status == NodeSuspect && time.Since(c.unreachable[name]) >= c.config.DownAfter {
+			c.markDown(node, &change)
+		}
+	}
+	c.nodesMutex.Unlock()
+
+	c.applyLifecycle(change)
+}
+
+func (c *Cluster) handleLeaving(ctx context.Context, msg *messaging.Message) error {
+	var change lifecycleChange
+
+	c.nodesMutex.Lock()
+	if node, exists := c.nodes[msg.Headers[HeaderSourceNode]]; exists && node.Status != NodeLeaving {
+		change.move(node, NodeLeaving)
+	}
+	c.nodesMutex.Unlock()
+
+	c.applyLifecycle(change)
+	return nil
+}
+
+func (c *Cluster) leaving() {
+	var change lifecycleChange
+
+	c.nodesMutex.Lock()
+	if self, exists := c.nodes[c.config.NodeName]; exists && self.Status != NodeLeaving {
+		change.move(self, NodeLeaving)
+	}
+	c.nodesMutex.Unlock()
+
+	c.applyLifecycle(change)
+
+	for _, node := range c.Members() {
+		if node.Name != c.config.NodeName && node.Status == NodeAlive {
+			_ = c.Send(node.Name, &messaging.Message{
+				Type:    messaging.System,
+				Headers: map[string]string{HeaderKind: KindLeaving},
+			})
+		}
+	}
+
+	c.listenersMu.RLock()
+	hooks := append([]func(){}, c.leaveHooks...)
+	c.listenersMu.RUnlock()
+
+	for _, hook := range hooks {
+		hook()
+	}
+}
+
+func (c *Cluster) applyLifecycle(change lifecycleChange) {
+	for _, node := range change.down {
+		c.releaseNode(node)
+		c.emitNodeEvent(NodeEvent{Type: NodeDown, Node: node})
+	}
+	for _, node := range change.up {
+		c.emitNodeEvent(NodeEvent{Type: NodeUp, Node: node})
+	}
+
+	if len(change.events) == 0 {
+		return
+	}
+	c.membersChangedAt.Store(time.Now().UnixNano())
+
+	c.memberSubsMu.RLock()
+	subscribers := append([]actor.ActorRef(nil), c.memberSubs...)
+	c.memberSubsMu.RUnlock()
+
+	if len(subscribers) == 0 {
+		return
+	}
+
+	c.enqueueNotification(func() {
+		for _, event := range change.events {
+			event := event
+			for _, sub := range subscribers {
+				ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+				_ = sub.Send(ctx, &event)
+				cancel()
+			}
+		}
+	})
+}
+
+// enqueueNotification hands actor notifications to the notifier goroutine so a
+// slow subscriber cannot hold up membership processing.
+func (c *Cluster) enqueueNotification(notify func()) {
+	c.notifyMu.Lock()
+	c.notifications = append(c.notifications, notify)
+	c.notifyMu.Unlock()
+
+	select {
+	case c.notifyWake <- struct{}{}:
+	default:
+	}
+}
+
+func (c *Cluster) deliverNotifications() {
+	c.notifyMu.Lock()
+	queue := c.notifications
+	c.notifications = nil
+	c.notifyMu.Unlock()
+
+	for _, notify := range queue {
+		notify()
+	}
+}
+
+func (c *Cluster) notifier() {
+	for {
+		select {
+		case <-c.stopCh:
+			c.deliverNotifications()
+			return
+		case <-c.notifyWake:
+			c.deliverNotifications()
+		}
+	}
+}
+
+func (c *Cluster) releaseNode(node string) {
+	c.remoteRefsMu.Lock()
+	defer c.remoteRefsMu.Unlock()
+
+	for key, ref := range c.remoteRefs {
+		if ref.address.Node == node {
+			delete(c.remoteRefs, key)
+		}
+	}
+}
+
+func newIncarnation() string {
+	return strconv.FormatInt(time.Now().UnixNano(), 10)
+}
@@ -0,0 +1,127 @@
+package cluster
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/memberlist"
+	"github.com/kleeedolinux/gorilix/messaging"
+)
+
+type eventRecorder struct {
+	events chan *MemberEvent
+}
+
+func (r *eventRecorder) Send(ctx context.Context, message interface{}) error {
+	if event, ok := message.(*MemberEvent); ok {
+		r.events <- event
+	}
+	return nil
+}
+
+func (r *eventRecorder) ID() string {
+	return "recorder"
+}
+
+func (r *eventRecorder) IsRunning() bool {
+	return true
+}
+
+func (r *eventRecorder) expect(t *testing.T, node string, statuses ...NodeStatus) {
+	t.Helper()
+	for _, status := range statuses {
+		select {
+		case event := <-r.events:
+			if event.Node != node || event.Status != status {
+				t.Fatalf("Expected %s to become %s, got %s -> %s", node, status, event.Node, event.Status)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Expected %s to become %s, got no event", node, status)
+		}
+	}
+}
+
+func TestNodeLifecycle(t *testing.T) {
+	c := NewCluster(&ClusterConfig{NodeName: "n1", DownAfter: 10 * time.Millisecond}, nil)
+	recorder := &eventRecorder{events: make(chan *MemberEvent, 20)}
+	c.SubscribeMembers(recorder)
+	go c.notifier()
+	t.Cleanup(func() { close(c.stopCh) })
+
+	var downs []string
+	c.OnNodeEvent(func(event NodeEvent) {
+		if event.Type == NodeDown {
+			downs = append(downs, event.Node)
+		}
+	})
+
+	c.nodeJoined(&memberlist.Node{Name: "n2"})
+	recorder.expect(t, "n2", NodeJoining, NodeAlive)
+
+	c.nodeLeft(&memberlist.Node{Name: "n2"})
+	recorder.expect(t, "n2", NodeSuspect)
+
+	c.nodeJoined(&memberlist.Node{Name: "n2"})
+	recorder.expect(t, "n2", NodeAlive)
+
+	c.nodeLeft(&memberlist.Node{Name: "n2"})
+	recorder.expect(t, "n2", NodeSuspect)
+	time.Sleep(20 * time.Millisecond)
+	c.checkMembers()
+	recorder.expect(t, "n2", NodeDead, NodeRemoved)
+	if _, exists := c.GetNode("n2"); exists || len(downs) != 1 {
+		t.Errorf("Expected n2 to be removed after one node down, got %v", downs)
+	}
+
+	c.nodeJoined(&memberlist.Node{Name: "n3"})
+	recorder.expect(t, "n3", NodeJoining, NodeAlive)
+	c.handleLeaving(context.Background(), &messaging.Message{Headers: map[string]string{HeaderSourceNode: "n3"}})
+	recorder.expect(t, "n3", NodeLeaving)
+	c.nodeLeft(&memberlist.Node{Name: "n3"})
+	recorder.expect(t, "n3", NodeDead, NodeRemoved)
+}
+
+type blockingSubscriber struct {
+	release chan struct{}
+}
+
+func (s *blockingSubscriber) Send(ctx context.Context, message interface{}) error {
+	select {
+	case <-s.release:
+	case <-ctx.Done():
+	}
+	return nil
+}
+
+func (s *blockingSubscriber) ID() string {
+	return "blocking"
+}
+
+func (s *blockingSubscriber) IsRunning() bool {
+	return true
+}
+
+func TestSlowSubscriberDoesNotBlockMembership(t *testing.T) {
+	c := NewCluster(&ClusterConfig{NodeName: "n1"}, nil)
+	slow := &blockingSubscriber{release: make(chan struct{})}
+	defer close(slow.release)
+	c.SubscribeMembers(slow)
+	go c.notifier()
+	t.Cleanup(func() { close(c.stopCh) })
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 5; i++ {
+			c.nodeJoined(&memberlist.Node{Name: "n2"})
+			c.nodeLeft(&memberlist.Node{Name: "n2"})
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(500 * time.Millisecond):
+		t.Fatal("Expected membership changes not to wait for a slow subscriber")
+	}
+}
@@ -0,0 +1,143 @@
+package cluster
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/memberlist"
+)
+
+const (
+	MetaRoles = "gorilix-roles"
+
+	reservedMetaPrefix = "gorilix-"
+	metaUpdateTimeout  = 5 * time.Second
+)
+
+func (c *Cluster) SetNodeMeta(key, value string) error {
+	if strings.HasPrefix(key, reservedMetaPrefix) {
+		return fmt.Errorf("%w: %s", ErrReservedMetaKey, key)
+	}
+	return c.updateMeta(key, value)
+}
+
+func (c *Cluster) DeleteNodeMeta(key string) error {
+	if strings.HasPrefix(key, reservedMetaPrefix) {
+		return fmt.Errorf("%w: %s", ErrReservedMetaKey, key)
+	}
+	return c.updateMeta(key, "")
+}
+
+func (c *Cluster) NodeMeta() map[string]string {
+	c.delegates.mtx.RLock()
+	defer c.delegates.mtx.RUnlock()
+
+	meta := make(map[string]string, len(c.delegates.metadata))
+	for k, v := range c.delegates.metadata {
+		meta[k] = v
+	}
+	return meta
+}
+
+func (c *Cluster) SetRoles(roles ...string) error {
+	for _, role := range roles {
+		if role == "" || strings.Contains(role, ",") {
+			return fmt.Errorf("invalid role %q", role)
+		}
+	}
+
+	sorted := append([]string(nil), roles...)
+	sort.Strings(sorted)
+	return c.updateMeta(MetaRoles, strings.Join(sorted, ","))
+}
+
+func (c *Cluster) Roles() []string {
+	return splitRoles(c.NodeMeta()[MetaRoles])
+}
+
+func (c *Cluster) MembersWithRole(role string) []*Node {
+	c.nodesMutex.RLock()
+	defer c.nodesMutex.RUnlock()
+
+	var members []*Node
+	for _, node := range c.nodes {
+		if node.Status == NodeAlive && node.HasRole(role) {
+			member := *node
+			members = append(members, &member)
+		}
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].Name < members[j].Name })
+	return members
+}
+
+func (n *Node) Roles() []string {
+	return splitRoles(n.Meta[MetaRoles])
+}
+
+func (n *Node) HasRole(role string) bool {
+	for _, r := range n.Roles() {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Cluster) updateMeta(key, value string) error {
+	if len(key) > 255 || len(value) > 255 {
+		return fmt.Errorf("%w: %s", ErrMetaTooLarge, key)
+	}
+
+	c.delegates.mtx.Lock()
+	previous, existed := c.delegates.metadata[key]
+	if value == "" {
+		delete(c.delegates.metadata, key)
+	} else {
+		c.delegates.metadata[key] = value
+	}
+
+	if c.delegates.metaSize() > memberlist.MetaMaxSize {
+		if existed {
+			c.delegates.metadata[key] = previous
+		} else {
+			delete(c.delegates.metadata, key)
+		}
+		c.delegates.mtx.Unlock()
+		return fmt.Errorf("%w: %s", ErrMetaTooLarge, key)
+	}
+	c.delegates.mtx.Unlock()
+
+	if c.running.Load() {
+		c.refreshSelf()
+		return c.memberlist.UpdateNode(metaUpdateTimeout)
+	}
+	return nil
+}
+
+func (c *Cluster) refreshSelf() {
+	meta := c.NodeMeta()
+
+	c.nodesMutex.Lock()
+	defer c.nodesMutex.Unlock()
+
+	if self, exists := c.nodes[c.config.NodeName]; exists {
+		self.Meta = meta
+	}
+}
+
+func (d *clusterDelegate) metaSize() int {
+	size := 0
+	for k, v := range d.metadata {
+		size += len(k) + len(v) + 2
+	}
+	return size
+}
+
+func splitRoles(roles string) []string {
+	if roles == "" {
+		return nil
+	}
+	return strings.Split(roles, ",")
+}
@@ -0,0 +1,60 @@
+package cluster_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kleeedolinux/gorilix/cluster"
+	"github.com/kleeedolinux/gorilix/cluster/internal/clustertest"
+)
+
+func roleNames(nodes []*cluster.Node) []string {
+	var names []string
+	for _, node := range nodes {
+		names = append(names, node.Name)
+	}
+	return names
+}
+
+func TestPeersSeeRolesAndMeta(t *testing.T) {
+	c1 := cluster.NewCluster(clustertest.Config("n1"), nil)
+	c2 := cluster.NewCluster(clustertest.Config("n2"), nil)
+	if err := c1.SetRoles("web"); err != nil {
+		t.Fatalf("SetRoles failed: %v", err)
+	}
+	if err := c1.SetNodeMeta(cluster.MetaRoles, "api"); !errors.Is(err, cluster.ErrReservedMetaKey) {
+		t.Errorf("Expected ErrReservedMetaKey, got %v", err)
+	}
+	clustertest.Start(t, c1)
+	clustertest.Start(t, c2)
+	clustertest.Join(t, c2, c1)
+
+	clustertest.WaitFor(t, "n2 to see n1 as web", func() bool {
+		web := c2.MembersWithRole("web")
+		return len(web) == 1 && web[0].Name == "n1"
+	})
+	if api := c2.MembersWithRole("api"); len(api) != 0 {
+		t.Errorf("Expected no api members yet, got %v", roleNames(api))
+	}
+
+	if err := c1.SetRoles("worker", "api"); err != nil {
+		t.Fatalf("SetRoles failed: %v", err)
+	}
+	if err := c1.SetNodeMeta("zone", "eu-west"); err != nil {
+		t.Fatalf("SetNodeMeta failed: %v", err)
+	}
+	if roles := c1.Roles(); len(roles) != 2 || roles[0] != "api" || roles[1] != "worker" {
+		t.Errorf("Expected sorted roles [api worker], got %v", roles)
+	}
+
+	clustertest.WaitFor(t, "n2 to see n1's updated meta", func() bool {
+		node, ok := c2.GetNode("n1")
+		return ok && node.HasRole("api") && node.HasRole("worker") && node.Meta["zone"] == "eu-west"
+	})
+	if web := c2.MembersWithRole("web"); len(web) != 0 {
+		t.Errorf("Expected n1 to have dropped the web role, got %v", roleNames(web))
+	}
+	if api := c1.MembersWithRole("api"); len(api) != 1 || api[0].Name != "n1" {
+		t.Errorf("Expected n1 to see itself as api, got %v", roleNames(api))
+	}
+}
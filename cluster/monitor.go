@@ -0,0 +1,146 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kleeedolinux/gorilix/actor"
+	"github.com/kleeedolinux/gorilix/messaging"
+)
+
+const (
+	KindMonitor   = "monitor"
+	KindDemonitor = "demonitor"
+	KindDown      = "down"
+)
+
+type monitorRequest struct {
+	Monitor   string            `json:"monitor"`
+	Monitored string            `json:"monitored"`
+	LinkType  actor.MonitorType `json:"link_type"`
+}
+
+type monitorReply struct {
+	ID string `json:"id"`
+}
+
+type downSignal struct {
+	Monitored string `json:"monitored"`
+	Reason    string `json:"reason"`
+}
+
+func (c *Cluster) Monitor(ctx context.Context, monitorID string, monitored actor.Address,
+	linkType actor.MonitorType) (actor.Address, error) {
+
+	reply, err := c.Request(ctx, monitored.Node, KindMonitor, monitorRequest{
+		Monitor:   c.qualify(monitorID),
+		Monitored: monitored.String(),
+		LinkType:  linkType,
+	})
+	if err != nil {
+		return actor.Address{}, err
+	}
+
+	var result monitorReply
+	if err := DecodePayload(reply, &result); err != nil {
+		return actor.Address{}, fmt.Errorf("invalid monitor reply from node %s: %w", monitored.Node, err)
+	}
+
+	return actor.Address{Node: monitored.Node, ID: result.ID}, nil
+}
+
+func (c *Cluster) Demonitor(monitorID string, monitored actor.Address) error {
+	return c.Send(monitored.Node, &messaging.Message{
+		Type: messaging.System,
+		Payload: monitorRequest{
+			Monitor:   c.qualify(monitorID),
+			Monitored: monitored.ID,
+		},
+		Headers: map[string]string{HeaderKind: KindDemonitor},
+	})
+}
+
+func (c *Cluster) sendDown(nodeName string, down *actor.MonitorMessage) error {
+	reason := ""
+	if down.Reason != nil {
+		reason = down.Reason.Error()
+	}
+
+	return c.Send(nodeName, &messaging.Message{
+		Type:      messaging.System,
+		Timestamp: time.Now(),
+		Payload: downSignal{
+			Monitored: c.qualify(down.MonitoredID),
+			Reason:    reason,
+		},
+		Headers: map[string]string{HeaderKind: KindDown},
+	})
+}
+
+func (c *Cluster) handleMonitor(ctx context.Context, msg *messaging.Message) (interface{}, error) {
+	if c.system == nil {
+		return nil, ErrInvalidSystemReference
+	}
+
+	var request monitorRequest
+	if err := DecodePayload(msg, &request); err != nil {
+		return nil, err
+	}
+
+	ref, err := c.system.GetActor(request.Monitored)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.system.Monitor(request.Monitor, ref.ID(), request.LinkType); err != nil {
+		return nil, err
+	}
+	return monitorReply{ID: ref.ID()}, nil
+}
+
+func (c *Cluster) handleDemonitor(ctx context.Context, msg *messaging.Message) error {
+	if c.system == nil {
+		return ErrInvalidSystemReference
+	}
+
+	var request monitorRequest
+	if err := DecodePayload(msg, &request); err != nil {
+		return nil
+	}
+
+	return c.system.Demonitor(request.Monitor, request.Monitored)
+}
+
+func (c *Cluster) handleDown(ctx context.Context, msg *messaging.Message) error {
+	if c.system == nil {
+		return ErrInvalidSystemReference
+	}
+
+	var signal downSignal
+	if err := DecodePayload(msg, &signal); err != nil {
+		return nil
+	}
+
+	return c.system.NotifyFailure(ctx, signal.Monitored, downReason(signal.Reason))
+}
+
+func (c *Cluster) qualify(actorID string) string {
+	if _, err := actor.ParseAddress(actorID); err == nil {
+		return actorID
+	}
+	return actor.Address{Node: c.config.NodeName, ID: actorID}.String()
+}
+
+func downReason(reason string) error {
+	switch reason {
+	case "":
+		return nil
+	case actor.ErrNoConnection.Error():
+		return actor.ErrNoConnection
+	case actor.ErrActorStopped.Error():
+		return actor.ErrActorStopped
+	}
+	return errors.New(reason)
+}
@@ -0,0 +1,449 @@
+package pg
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kleeedolinux/gorilix/actor"
+	"github.com/kleeedolinux/gorilix/cluster"
+	"github.com/kleeedolinux/gorilix/messaging"
+)
+
+const (
+	KindJoin  = "pg-join"
+	KindLeave = "pg-leave"
+
+	stateName     = "pg"
+	sweepInterval = time.Second
+)
+
+var ErrNotLocalActor = errors.New("only local actors can join a process group")
+
+type EventType int
+
+const (
+	MembersJoined EventType = iota
+	MembersLeft
+)
+
+type MembershipEvent struct {
+	Group   string
+	Type    EventType
+	Members []actor.Address
+}
+
+type membershipChange struct {
+	Node     string   `json:"node"`
+	Group    string   `json:"group"`
+	ActorIDs []string `json:"actor_ids"`
+}
+
+type nodeState struct {
+	Node   string              `json:"node"`
+	Groups map[string][]string `json:"groups"`
+}
+
+type notification struct {
+	subscribers []actor.ActorRef
+	event       *MembershipEvent
+}
+
+type Groups struct {
+	cluster     *cluster.Cluster
+	members     map[string]map[actor.Address]struct{}
+	local       map[string]map[string]actor.ActorRef
+	subscribers map[string][]actor.ActorRef
+	queue       []notification
+	queueMu     sync.Mutex
+	wake        chan struct{}
+	mu          sync.RWMutex
+}
+
+func NewGroups(c *cluster.Cluster) *Groups {
+	g := &Groups{
+		cluster:     c,
+		members:     make(map[string]map[actor.Address]struct{}),
+		local:       make(map[string]map[string]actor.ActorRef),
+		subscribers: make(map[string][]actor.ActorRef),
+		wake:        make(chan struct{}, 1),
+	}
+
+	c.HandleKind(KindJoin, g.handleJoin)
+	c.HandleKind(KindLeave, g.handleLeave)
+	c.RegisterState(stateName, g)
+	c.OnNodeEvent(g.handleNodeEvent)
+	c.OnStart(func() { go g.run() })
+
+	return g
+}
+
+func (g *Groups) Join(group string, refs ...actor.ActorRef) error {
+	self := g.cluster.NodeName()
+
+	for _, ref := range refs {
+		if address, err := actor.ParseAddress(ref.ID()); err == nil && address.Node != self {
+			return ErrNotLocalActor
+		}
+	}
+
+	g.mu.Lock()
+	var ids []string
+	for _, ref := range refs {
+		if _, exists := g.local[group][ref.ID()]; exists {
+			continue
+		}
+		if g.local[group] == nil {
+			g.local[group] = make(map[string]actor.ActorRef)
+		}
+		g.local[group][ref.ID()] = ref
+		ids = append(ids, ref.ID())
+	}
+	added := g.add(group, self, ids)
+	g.mu.Unlock()
+
+	if len(added) == 0 {
+		return nil
+	}
+
+	g.notify(group, MembersJoined, added)
+	g.broadcast(KindJoin, membershipChange{Node: self, Group: group, ActorIDs: ids})
+	return nil
+}
+
+func (g *Groups) Leave(group string, refs ...actor.ActorRef) error {
+	ids := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		ids = append(ids, ref.ID())
+	}
+	g.leave(group, ids)
+	return nil
+}
+
+func (g *Groups) GetMembers(group string) []actor.ActorRef {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	self := g.cluster.NodeName()
+	refs := make([]actor.ActorRef, 0, len(g.members[group]))
+	for _, address := range sortedAddresses(g.members[group]) {
+		if address.Node == self {
+			if ref, exists := g.local[group][address.ID]; exists {
+				refs = append(refs, ref)
+			}
+			continue
+		}
+		refs = append(refs, g.cluster.RemoteRef(address))
+	}
+	return refs
+}
+
+func (g *Groups) GetLocalMembers(group string) []actor.ActorRef {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	ids := make([]string, 0, len(g.local[group]))
+	for id := range g.local[group] {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	refs := make([]actor.ActorRef, 0, len(ids))
+	for _, id := range ids {
+		refs = append(refs, g.local[group][id])
+	}
+	return refs
+}
+
+func (g *Groups) WhichGroups() []string {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+
+	groups := make([]string, 0, len(g.members))
+	for group := range g.members {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+	return groups
+}
+
+func (g *Groups) Subscribe(group string, subscriber actor.ActorRef) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for _, sub := range g.subscribers[group] {
+		if sub.ID() == subscriber.ID() {
+			return
+		}
+	}
+
+	g.subscribers[group] = append(g.subscribers[group], subscriber)
+}
+
+func (g *Groups) Unsubscribe(group string, subscriberID string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	var subs []actor.ActorRef
+	for _, sub := range g.subscribers[group] {
+		if sub.ID() != subscriberID {
+			subs = append(subs, sub)
+		}
+	}
+
+	g.subscribers[group] = subs
+}
+
+func (g *Groups) LocalState() []byte {
+	g.mu.RLock()
+	state := nodeState{Node: g.cluster.NodeName(), Groups: make(map[string][]string, len(g.local))}
+	for group, refs := range g.local {
+		for id := range refs {
+			state.Groups[group] = append(state.Groups[group], id)
+		}
+	}
+	g.mu.RUnlock()
+
+	data, _ := json.Marshal(state)
+	return data
+}
+
+func (g *Groups) MergeRemoteState(data []byte) {
+	var state nodeState
+	if err := json.Unmarshal(data, &state); err != nil || state.Node == g.cluster.NodeName() {
+		return
+	}
+
+	g.replaceNode(state.Node, state.Groups)
+}
+
+func (g *Groups) handleJoin(ctx context.Context, msg *messaging.Message) error {
+	var change membershipChange
+	if err := cluster.DecodePayload(msg, &change); err != nil {
+		return nil
+	}
+
+	g.mu.Lock()
+	added := g.add(change.Group, change.Node, change.ActorIDs)
+	g.mu.Unlock()
+
+	g.notify(change.Group, MembersJoined, added)
+	return nil
+}
+
+func (g *Groups) handleLeave(ctx context.Context, msg *messaging.Message) error {
+	var change membershipChange
+	if err := cluster.DecodePayload(msg, &change); err != nil {
+		return nil
+	}
+
+	g.mu.Lock()
+	removed := g.remove(change.Group, change.Node, change.ActorIDs)
+	g.mu.Unlock()
+
+	g.notify(change.Group, MembersLeft, removed)
+	return nil
+}
+
+func (g *Groups) handleNodeEvent(event cluster.NodeEvent) {
+	if event.Type == cluster.NodeDown {
+		g.replaceNode(event.Node, nil)
+	}
+}
+
+func (g *Groups) replaceNode(node string, groups map[string][]string) {
+	changes := make(map[string][2][]actor.Address)
+
+	g.mu.Lock()
+	for group, members := range g.members {
+		wanted := make(map[string]struct{}, len(groups[group]))
+		for _, id := range groups[group] {
+			wanted[id] = struct{}{}
+		}
+
+		var stale []string
+		for address := range members {
+			if _, keep := wanted[address.ID]; address.Node == node && !keep {
+				stale = append(stale, address.ID)
+			}
+		}
+		if removed := g.remove(group, node, stale); len(removed) > 0 {
+			change := changes[group]
+			change[1] = removed
+			changes[group] = change
+		}
+	}
+
+	for group, ids := range groups {
+		if added := g.add(group, node, ids); len(added) > 0 {
+			change := changes[group]
+			change[0] = added
+			changes[group] = change
+		}
+	}
+	g.mu.Unlock()
+
+	for group, change := range changes {
+		g.notify(group, MembersJoined, change[0])
+		g.notify(group, MembersLeft, change[1])
+	}
+}
+
+func (g *Groups) leave(group string, ids []string) {
+	self := g.cluster.NodeName()
+
+	g.mu.Lock()
+	var left []string
+	for _, id := range ids {
+		if _, exists := g.local[group][id]; exists {
+			delete(g.local[group], id)
+			left = append(left, id)
+		}
+	}
+	if len(g.local[group]) == 0 {
+		delete(g.local, group)
+	}
+	removed := g.remove(group, self, left)
+	g.mu.Unlock()
+
+	if len(removed) == 0 {
+		return
+	}
+
+	g.notify(group, MembersLeft, removed)
+	g.broadcast(KindLeave, membershipChange{Node: self, Group: group, ActorIDs: left})
+}
+
+func (g *Groups) add(group, node string, ids []string) []actor.Address {
+	var added []actor.Address
+	for _, id := range ids {
+		address := actor.Address{Node: node, ID: id}
+		if _, exists := g.members[group][address]; exists {
+			continue
+		}
+		if g.members[group] == nil {
+			g.members[group] = make(map[actor.Address]struct{})
+		}
+		g.members[group][address] = struct{}{}
+		added = append(added, address)
+	}
+	return added
+}
+
+func (g *Groups) remove(group, node string, ids []string) []actor.Address {
+	var removed []actor.Address
+	for _, id := range ids {
+		address := actor.Address{Node: node, ID: id}
+		if _, exists := g.members[group][address]; exists {
+			delete(g.members[group], address)
+			removed = append(removed, address)
+		}
+	}
+	if len(g.members[group]) == 0 {
+		delete(g.members, group)
+	}
+	return removed
+}
+
+func (g *Groups) notify(group string, eventType EventType, members []actor.Address) {
+	if len(members) == 0 {
+		return
+	}
+
+	g.mu.RLock()
+	subscribers := append([]actor.ActorRef(nil), g.subscribers[group]...)
+	g.mu.RUnlock()
+
+	if len(subscribers) == 0 {
+		return
+	}
+
+	g.queueMu.Lock()
+	g.queue = append(g.queue, notification{
+		subscribers: subscribers,
+		event:       &MembershipEvent{Group: group, Type: eventType, Members: members},
+	})
+	g.queueMu.Unlock()
+
+	select {
+	case g.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (g *Groups) deliver() {
+	g.queueMu.Lock()
+	queue := g.queue
+	g.queue = nil
+	g.queueMu.Unlock()
+
+	for _, n := range queue {
+		for _, sub := range n.subscribers {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			_ = sub.Send(ctx, n.event)
+			cancel()
+		}
+	}
+}
+
+func (g *Groups) broadcast(kind string, change membershipChange) {
+	for _, node := range g.cluster.Members() {
+		if node.Name == change.Node || node.Status != cluster.NodeAlive {
+			continue
+		}
+
+		_ = g.cluster.Send(node.Name, &messaging.Message{
+			Type:    messaging.System,
+			Payload: change,
+			Headers: map[string]string{cluster.HeaderKind: kind},
+		})
+	}
+}
+
+func (g *Groups) run() {
+	ticker := time.NewTicker(sweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-g.cluster.Done():
+			return
+		case <-g.wake:
+			g.deliver()
+		case <-ticker.C:
+			g.sweep()
+		}
+	}
+}
+
+func (g *Groups) sweep() {
+	dead := make(map[string][]string)
+
+	g.mu.RLock()
+	for group, refs := range g.local {
+		for id, ref := range refs {
+			if !ref.IsRunning() {
+				dead[group] = append(dead[group], id)
+			}
+		}
+	}
+	g.mu.RUnlock()
+
+	for group, ids := range dead {
+		g.leave(group, ids)
+	}
+}
+
+func sortedAddresses(members map[actor.Address]struct{}) []actor.Address {
+	addresses := make([]actor.Address, 0, len(members))
+	for address := range members {
+		addresses = append(addresses, address)
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		return addresses[i].String() < addresses[j].String()
+	})
+	return addresses
+}
@@ -0,0 +1,106 @@
+package pg
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/kleeedolinux/gorilix/actor"
+	"github.com/kleeedolinux/gorilix/cluster"
+	"github.com/kleeedolinux/gorilix/cluster/internal/clustertest"
+)
+
+func events(r *clustertest.Recorder) []MembershipEvent {
+	var events []MembershipEvent
+	for {
+		select {
+		case msg := <-r.Messages:
+			events = append(events, *msg.(*MembershipEvent))
+		default:
+			return events
+		}
+	}
+}
+
+func newTestGroups() *Groups {
+	return NewGroups(cluster.NewCluster(&cluster.ClusterConfig{NodeName: "n1"}, nil))
+}
+
+func remoteState(node string, groups map[string][]string) []byte {
+	data, _ := json.Marshal(nodeState{Node: node, Groups: groups})
+	return data
+}
+
+func TestNotificationsAreQueuedInOrder(t *testing.T) {
+	g := newTestGroups()
+	watcher := clustertest.NewRecorder("watcher")
+	g.Subscribe("workers", watcher)
+
+	worker := clustertest.NewRecorder("worker")
+	if err := g.Join("workers", worker); err != nil {
+		t.Fatalf("Join failed: %v", err)
+	}
+	_ = g.Leave("workers", worker)
+
+	if events := events(watcher); len(events) != 0 {
+		t.Fatalf("Expected notifications to be queued, got %+v", events)
+	}
+
+	g.deliver()
+
+	member := []actor.Address{{Node: "n1", ID: "worker"}}
+	want := []MembershipEvent{
+		{Group: "workers", Type: MembersJoined, Members: member},
+		{Group: "workers", Type: MembersLeft, Members: member},
+	}
+	if events := events(watcher); !reflect.DeepEqual(events, want) {
+		t.Errorf("Expected %+v, got %+v", want, events)
+	}
+}
+
+func TestNodeDownRemovesMembers(t *testing.T) {
+	g := newTestGroups()
+	watcher := clustertest.NewRecorder("watcher")
+	g.Subscribe("workers", watcher)
+
+	if err := g.Join("workers", clustertest.NewRecorder("local")); err != nil {
+		t.Fatalf("Join failed: %v", err)
+	}
+	g.MergeRemoteState(remoteState("n2", map[string][]string{"workers": {"a", "b"}, "cache": {"c"}}))
+	g.MergeRemoteState(remoteState("n3", map[string][]string{"workers": {"d"}}))
+	g.deliver()
+	events(watcher)
+
+	g.handleNodeEvent(cluster.NodeEvent{Type: cluster.NodeDown, Node: "n2"})
+
+	var ids []string
+	for _, ref := range g.GetMembers("workers") {
+		ids = append(ids, ref.ID())
+	}
+	if want := []string{"d@n3", "local"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("Expected members %v after n2 went down, got %v", want, ids)
+	}
+	if groups := g.WhichGroups(); !reflect.DeepEqual(groups, []string{"workers"}) {
+		t.Errorf("Expected empty groups to be removed, got %v", groups)
+	}
+
+	g.deliver()
+	events := events(watcher)
+	if len(events) != 1 || events[0].Type != MembersLeft || len(events[0].Members) != 2 {
+		t.Errorf("Expected one left event for n2's two workers, got %+v", events)
+	}
+}
+
+func TestMergeReplacesNodeMembership(t *testing.T) {
+	g := newTestGroups()
+	g.MergeRemoteState(remoteState("n2", map[string][]string{"workers": {"a", "b"}}))
+	g.MergeRemoteState(remoteState("n2", map[string][]string{"workers": {"b", "c"}}))
+
+	var ids []string
+	for _, ref := range g.GetMembers("workers") {
+		ids = append(ids, ref.ID())
+	}
+	if want := []string{"b@n2", "c@n2"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("Expected members %v, got %v", want, ids)
+	}
+}
@@ -0,0 +1,108 @@
+package cluster
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/kleeedolinux/gorilix/actor"
+	"github.com/kleeedolinux/gorilix/messaging"
+)
+
+const HeaderRawPayload = "gorilix-raw-payload"
+
+type RemoteRef struct {
+	cluster  *Cluster
+	address  actor.Address
+	notFound atomic.Bool
+}
+
+func (c *Cluster) RemoteRef(address actor.Address) *RemoteRef {
+	key := address.String()
+
+	c.remoteRefsMu.Lock()
+	defer c.remoteRefsMu.Unlock()
+
+	if ref, exists := c.remoteRefs[key]; exists {
+		return ref
+	}
+
+	ref := &RemoteRef{cluster: c, address: address}
+	c.remoteRefs[key] = ref
+	return ref
+}
+
+func (r *RemoteRef) Send(ctx context.Context, message interface{}) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if down, ok := message.(*actor.MonitorMessage); ok {
+		return r.cluster.sendDown(r.address.Node, down)
+	}
+
+	msg, ok := message.(messaging.Message)
+	if !ok {
+		msg = messaging.Message{
+			Type:    messaging.Normal,
+			Payload: message,
+			Headers: map[string]string{HeaderRawPayload: "true"},
+		}
+	}
+
+	if r.address.IsNamed() {
+		msg.Receiver = r.address.Name
+		msg.Headers = withHeader(msg.Headers, HeaderReceiverType, ReceiverByName)
+	} else {
+		msg.Receiver = r.address.ID
+		msg.Headers = withHeader(msg.Headers, HeaderReceiverType, ReceiverByID)
+	}
+
+	err := r.cluster.deliver(ctx, r.address.Node, &msg)
+	if err == nil {
+		r.notFound.Store(false)
+	}
+	return err
+}
+
+func (r *RemoteRef) ID() string {
+	return r.address.String()
+}
+
+func (r *RemoteRef) Address() actor.Address {
+	return r.address
+}
+
+func (r *RemoteRef) Node() string {
+	return r.address.Node
+}
+
+func (r *RemoteRef) IsRunning() bool {
+	if r.notFound.Load() {
+		return false
+	}
+
+	if r.address.Node == r.cluster.config.NodeName {
+		return r.cluster.running.Load()
+	}
+
+	node, exists := r.cluster.GetNode(r.address.Node)
+	return exists && node.Status == NodeAlive
+}
+
+func (c *Cluster) markRemoteRefs(failure DeliveryFailure) {
+	if !failure.NotFound {
+		return
+	}
+
+	c.remoteRefsMu.RLock()
+	defer c.remoteRefsMu.RUnlock()
+
+	for _, address := range []actor.Address{
+		{Node: failure.Node, ID: failure.Receiver},
+		{Node: failure.Node, Name: failure.Receiver},
+	} {
+		if ref, exists := c.remoteRefs[address.String()]; exists {
+			ref.notFound.Store(true)
+		}
+	}
+}
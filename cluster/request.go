@@ -0,0 +1,106 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kleeedolinux/gorilix/messaging"
+)
+
+const (
+	KindReply   = "reply"
+	HeaderError = "gorilix-error"
+)
+
+type RequestHandler func(ctx context.Context, msg *messaging.Message) (interface{}, error)
+
+type RemoteError struct {
+	Node    string
+	Message string
+}
+
+func (e *RemoteError) Error() string {
+	return fmt.Sprintf("remote error from node %s: %s", e.Node, e.Message)
+}
+
+func (c *Cluster) Request(ctx context.Context, nodeName, kind string, payload interface{}) (*messaging.Message, error) {
+	id := c.NewMessageID()
+	replyCh := make(chan *messaging.Message, 1)
+
+	c.pendingMu.Lock()
+	c.pending[id] = replyCh
+	c.pendingMu.Unlock()
+
+	defer func() {
+		c.pendingMu.Lock()
+		delete(c.pending, id)
+		c.pendingMu.Unlock()
+	}()
+
+	request := &messaging.Message{
+		Type:      messaging.System,
+		ID:        id,
+		Payload:   payload,
+		Timestamp: time.Now(),
+		Headers:   map[string]string{HeaderKind: kind},
+	}
+
+	if err := c.Send(nodeName, request); err != nil {
+		return nil, err
+	}
+
+	select {
+	case reply := <-replyCh:
+		if errText, failed := reply.Headers[HeaderError]; failed {
+			return nil, &RemoteError{Node: nodeName, Message: errText}
+		}
+		return reply, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (c *Cluster) HandleRequest(kind string, handler RequestHandler) {
+	c.HandleKind(kind, func(ctx context.Context, msg *messaging.Message) error {
+		result, err := handler(ctx, msg)
+
+		reply := &messaging.Message{
+			Type:      messaging.System,
+			Payload:   result,
+			Timestamp: time.Now(),
+			Headers: map[string]string{
+				HeaderKind:          KindReply,
+				HeaderCorrelationID: msg.ID,
+			},
+		}
+		if err != nil {
+			reply.Payload = nil
+			reply.Headers[HeaderError] = err.Error()
+		}
+
+		return c.Send(msg.Headers[HeaderSourceNode], reply)
+	})
+}
+
+func (c *Cluster) handleReply(ctx context.Context, msg *messaging.Message) error {
+	c.resolvePending(msg.Headers[HeaderCorrelationID], msg)
+	return nil
+}
+
+func (c *Cluster) resolvePending(id string, reply *messaging.Message) bool {
+	c.pendingMu.Lock()
+	replyCh, exists := c.pending[id]
+	c.pendingMu.Unlock()
+
+	if !exists {
+		return false
+	}
+
+	select {
+	case replyCh <- reply:
+	default:
+
+	}
+	return true
+}
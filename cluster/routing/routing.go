@@ -0,0 +1,181 @@
+package routing
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/kleeedolinux/gorilix/actor"
+	"github.com/kleeedolinux/gorilix/cluster"
+	"github.com/kleeedolinux/gorilix/messaging"
+)
+
+const DefaultReplicas = 100
+
+var (
+	ErrNoRoutee = errors.New("no node available for routing")
+)
+
+type Hasher interface {
+	Set(nodes []string)
+	Get(key string) (string, bool)
+}
+
+type Ring struct {
+	replicas int
+	hashes   []uint64
+	owners   map[uint64]string
+}
+
+func NewRing(replicas int) *Ring {
+	if replicas <= 0 {
+		replicas = DefaultReplicas
+	}
+	return &Ring{replicas: replicas, owners: make(map[uint64]string)}
+}
+
+func (r *Ring) Set(nodes []string) {
+	r.hashes = make([]uint64, 0, len(nodes)*r.replicas)
+	r.owners = make(map[uint64]string, len(nodes)*r.replicas)
+
+	for _, node := range nodes {
+		for i := 0; i < r.replicas; i++ {
+			h := hash(node + "#" + strconv.Itoa(i))
+			if owner, exists := r.owners[h]; exists {
+				if node < owner {
+					r.owners[h] = node
+				}
+				continue
+			}
+			r.hashes = append(r.hashes, h)
+			r.owners[h] = node
+		}
+	}
+	sort.Slice(r.hashes, func(i, j int) bool { return r.hashes[i] < r.hashes[j] })
+}
+
+func (r *Ring) Get(key string) (string, bool) {
+	if len(r.hashes) == 0 {
+		return "", false
+	}
+
+	h := hash(key)
+	i := sort.Search(len(r.hashes), func(i int) bool { return r.hashes[i] >= h })
+	if i == len(r.hashes) {
+		i = 0
+	}
+	return r.owners[r.hashes[i]], true
+}
+
+type Rendezvous struct {
+	nodes []string
+}
+
+func NewRendezvous() *Rendezvous {
+	return &Rendezvous{}
+}
+
+func (r *Rendezvous) Set(nodes []string) {
+	r.nodes = append([]string(nil), nodes...)
+}
+
+func (r *Rendezvous) Get(key string) (string, bool) {
+	var owner string
+	var best uint64
+	for _, node := range r.nodes {
+		if weight := hash(key + "#" + node); owner == "" || weight > best || weight == best && node < owner {
+			owner, best = node, weight
+		}
+	}
+	return owner, owner != ""
+}
+
+type Router struct {
+	cluster *cluster.Cluster
+	role    string
+	hasher  Hasher
+	members string
+	mu      sync.Mutex
+}
+
+func NewRouter(c *cluster.Cluster, role string, hasher Hasher) *Router {
+	return &Router{cluster: c, role: role, hasher: hasher}
+}
+
+func NewConsistentHashRouter(c *cluster.Cluster, role string, replicas int) *Router {
+	return NewRouter(c, role, NewRing(replicas))
+}
+
+func NewRendezvousRouter(c *cluster.Cluster, role string) *Router {
+	return NewRouter(c, role, NewRendezvous())
+}
+
+func (r *Router) Node(key string) (string, bool) {
+	var nodes []string
+	for _, node := range r.cluster.Members() {
+		if node.Status == cluster.NodeAlive && (r.role == "" || node.HasRole(r.role)) {
+			nodes = append(nodes, node.Name)
+		}
+	}
+	sort.Strings(nodes)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if members := strings.Join(nodes, ","); members != r.members {
+		r.hasher.Set(nodes)
+		r.members = members
+	}
+	return r.hasher.Get(key)
+}
+
+func (r *Router) SendToNode(key string, msg []byte) error {
+	node, ok := r.Node(key)
+	if !ok {
+		return ErrNoRoutee
+	}
+	return r.cluster.SendToNode(node, msg)
+}
+
+func (r *Router) Send(key string, msg *messaging.Message) error {
+	node, ok := r.Node(key)
+	if !ok {
+		return ErrNoRoutee
+	}
+	return r.cluster.Send(node, msg)
+}
+
+func (r *Router) Ref(key string, actorID string) (actor.ActorRef, error) {
+	node, ok := r.Node(key)
+	if !ok {
+		return nil, ErrNoRoutee
+	}
+	return r.cluster.RemoteRef(actor.Address{Node: node, ID: actorID}), nil
+}
+
+func (r *Router) SendTo(ctx context.Context, key string, actorID string, message interface{}) error {
+	ref, err := r.Ref(key, actorID)
+	if err != nil {
+		return err
+	}
+	return ref.Send(ctx, message)
+}
+
+// hash mixes the FNV-1a sum so that keys differing only in their last bytes
+// still land far apart on the ring.
+func hash(s string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+
+	x := h.Sum64()
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
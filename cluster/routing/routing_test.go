@@ -0,0 +1,55 @@
+package routing
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestHashersRemapMinimally(t *testing.T) {
+	hashers := map[string]func() Hasher{
+		"ring":       func() Hasher { return NewRing(DefaultReplicas) },
+		"rendezvous": func() Hasher { return NewRendezvous() },
+	}
+
+	for name, newHasher := range hashers {
+		t.Run(name, func(t *testing.T) {
+			hasher := newHasher()
+			if _, ok := hasher.Get("key"); ok {
+				t.Fatal("Expected no node for an empty hasher")
+			}
+
+			hasher.Set([]string{"n1", "n2", "n3", "n4"})
+			before := make(map[string]string)
+			counts := make(map[string]int)
+			for i := 0; i < 4000; i++ {
+				key := fmt.Sprintf("key-%d", i)
+				node, _ := hasher.Get(key)
+				before[key] = node
+				counts[node]++
+			}
+			for _, node := range []string{"n1", "n2", "n3", "n4"} {
+				if counts[node] < 500 {
+					t.Errorf("Expected keys to spread over nodes, got %v", counts)
+				}
+			}
+
+			hasher.Set([]string{"n1", "n2", "n4"})
+			for key, previous := range before {
+				node, _ := hasher.Get(key)
+				if previous != "n3" && node != previous {
+					t.Fatalf("Expected %s to stay on %s after n3 left, got %s", key, previous, node)
+				}
+				if node == "n3" {
+					t.Fatalf("Expected %s to move off n3", key)
+				}
+			}
+
+			hasher.Set([]string{"n1", "n2", "n3", "n4"})
+			for key, previous := range before {
+				if node, _ := hasher.Get(key); node != previous {
+					t.Fatalf("Expected %s to return to %s after n3 rejoined, got %s", key, previous, node)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/kleeedolinux/gorilix/messaging"
+	"github.com/kleeedolinux/gorilix/serialization"
+)
+
+const (
+	KindSchemaState  = "schema-state"
+	schemasStateName = "schemas"
+)
+
+type DeadLetter struct {
+	Node     string
+	Message  *messaging.Message
+	Manifest serialization.Manifest
+	Reason   string
+	Received time.Time
+}
+
+type schemaState struct {
+	Node     string         `json:"node"`
+	Versions map[string]int `json:"versions"`
+}
+
+type schemaBook struct {
+	cluster *Cluster
+	remote  map[string]map[string]int
+	mu      sync.RWMutex
+}
+
+func newSchemaBook(c *Cluster) *schemaBook {
+	s := &schemaBook{
+		cluster: c,
+		remote:  make(map[string]map[string]int),
+	}
+
+	c.HandleKind(KindSchemaState, s.handleState)
+	c.RegisterState(schemasStateName, s)
+	c.OnNodeEvent(s.handleNodeEvent)
+
+	return s
+}
+
+func (c *Cluster) DeadLetters() <-chan DeadLetter {
+	return c.deadLetters
+}
+
+func (c *Cluster) deadLetter(msg *messaging.Message, reason error) {
+	letter := DeadLetter{
+		Node:     msg.Headers[HeaderSourceNode],
+		Message:  msg,
+		Reason:   reason.Error(),
+		Received: time.Now(),
+	}
+	if encoded, ok := msg.Payload.(serialization.Encoded); ok {
+		letter.Manifest = encoded.Manifest
+	}
+
+	select {
+	case c.deadLetters <- letter:
+	default:
+
+	}
+}
+
+func (s *schemaBook) LocalState() []byte {
+	data, _ := json.Marshal(s.localState())
+	return data
+}
+
+func (s *schemaBook) MergeRemoteState(data []byte) {
+	var state schemaState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return
+	}
+	s.replace(state)
+}
+
+func (s *schemaBook) handleState(ctx context.Context, msg *messaging.Message) error {
+	var state schemaState
+	if err := DecodePayload(msg, &state); err != nil {
+		return nil
+	}
+	s.replace(state)
+	return nil
+}
+
+func (s *schemaBook) handleNodeEvent(event NodeEvent) {
+	if event.Type == NodeUp {
+		_ = s.cluster.Send(event.Node, &messaging.Message{
+			Type:    messaging.System,
+			Payload: s.localState(),
+			Headers: map[string]string{HeaderKind: KindSchemaState},
+		})
+		return
+	}
+
+	if event.Type == NodeDown {
+		s.mu.Lock()
+		delete(s.remote, event.Node)
+		s.mu.Unlock()
+	}
+}
+
+func (s *schemaBook) localState() schemaState {
+	return schemaState{Node: s.cluster.config.NodeName, Versions: serialization.DefaultRegistry.Versions()}
+}
+
+func (s *schemaBook) replace(state schemaState) {
+	if state.Node == "" || state.Node == s.cluster.config.NodeName {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.remote[state.Node] = state.Versions
+}
+
+func (s *schemaBook) peerVersion(nodeName, typeName string) (int, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if nodeName != "" {
+		version, exists := s.remote[nodeName][typeName]
+		return version, exists
+	}
+
+	lowest, found := 0, false
+	for _, versions := range s.remote {
+		if version, exists := versions[typeName]; exists && (!found || version < lowest) {
+			lowest, found = version, true
+		}
+	}
+	return lowest, found
+}
+
+func (s *schemaBook) downcast(nodeName string, msg *messaging.Message) *messaging.Message {
+	if nodeName == s.cluster.config.NodeName {
+		return msg
+	}
+
+	manifest, registered := serialization.DefaultRegistry.Manifest(msg.Payload)
+	if !registered {
+		return msg
+	}
+
+	version, known := s.peerVersion(nodeName, manifest.Type)
+	if !known || version >= manifest.Version {
+		return msg
+	}
+
+	encoded, err := serialization.DefaultRegistry.EncodeVersion(msg.Payload, version)
+	if err != nil {
+		return msg
+	}
+
+	out := *msg
+	out.Payload = encoded
+	return &out
+}
@@ -0,0 +1,312 @@
+package cluster
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/memberlist"
+)
+
+const (
+	MetaAdmission = "gorilix-admission"
+
+	sealedFrameMarker = 0x01
+	sealStampSize     = 16
+	sealHeaderSize    = 1 + sealStampSize + sha256.Size
+
+	admissionWindow  = 5 * time.Minute
+	admissionRefresh = time.Minute
+	replayWindow     = 2 * time.Minute
+
+	tlsHandshakeTimeout = 10 * time.Second
+)
+
+func (c *Cluster) InstallKey(key []byte) error {
+	if c.keyring == nil {
+		return ErrEncryptionNotEnabled
+	}
+	return c.keyring.AddKey(key)
+}
+
+func (c *Cluster) UseKey(key []byte) error {
+	if c.keyring == nil {
+		return ErrEncryptionNotEnabled
+	}
+	return c.keyring.UseKey(key)
+}
+
+func (c *Cluster) RemoveKey(key []byte) error {
+	if c.keyring == nil {
+		return ErrEncryptionNotEnabled
+	}
+	return c.keyring.RemoveKey(key)
+}
+
+func (c *Cluster) Keys() [][]byte {
+	if c.keyring == nil {
+		return nil
+	}
+	return c.keyring.GetKeys()
+}
+
+func newKeyring(keys [][]byte) (*memberlist.Keyring, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	keyring, err := memberlist.NewKeyring(keys, keys[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid secret keys: %w", err)
+	}
+	return keyring, nil
+}
+
+func admissionToken(secret, nodeName string, issued time.Time) string {
+	stamp := strconv.FormatInt(issued.UnixNano(), 10)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(nodeName))
+	mac.Write([]byte{0})
+	mac.Write([]byte(stamp))
+	return stamp + "." + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (c *Cluster) admit(node *memberlist.Node) error {
+	if c.config.AdmissionSecret == "" {
+		return nil
+	}
+
+	token := decodeNodeMeta(node.Meta)[MetaAdmission]
+	stamp, _, _ := strings.Cut(token, ".")
+	nanos, err := strconv.ParseInt(stamp, 10, 64)
+	if err != nil || !hmac.Equal([]byte(token), []byte(admissionToken(c.config.AdmissionSecret, node.Name, time.Unix(0, nanos)))) {
+		return fmt.Errorf("%w: %s", ErrAdmissionDenied, node.Name)
+	}
+
+	if age := time.Since(time.Unix(0, nanos)); age > admissionWindow || age < -admissionWindow {
+		return fmt.Errorf("%w: %s: token expired", ErrAdmissionDenied, node.Name)
+	}
+
+	c.admittedMu.Lock()
+	defer c.admittedMu.Unlock()
+
+	if nanos < c.admitted[node.Name] {
+		return fmt.Errorf("%w: %s: token replayed", ErrAdmissionDenied, node.Name)
+	}
+	c.admitted[node.Name] = nanos
+	return nil
+}
+
+func (c *Cluster) refreshAdmission() {
+	ticker := time.NewTicker(admissionRefresh)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.stopCh:
+			return
+		case <-ticker.C:
+			_ = c.updateMeta(MetaAdmission, admissionToken(c.config.AdmissionSecret, c.config.NodeName, time.Now()))
+		}
+	}
+}
+
+type admissionDelegate struct {
+	cluster *Cluster
+}
+
+func (d *admissionDelegate) NotifyAlive(peer *memberlist.Node) error {
+	return d.cluster.admit(peer)
+}
+
+func (d *admissionDelegate) NotifyMerge(peers []*memberlist.Node) error {
+	for _, peer := range peers {
+		if err := d.cluster.admit(peer); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Cluster) seal(frame []byte) []byte {
+	if c.config.AdmissionSecret == "" {
+		return frame
+	}
+
+	sealed := make([]byte, 1+sealStampSize, sealHeaderSize+len(frame))
+	sealed[0] = sealedFrameMarker
+	binary.BigEndian.PutUint64(sealed[1:9], uint64(time.Now().UnixNano()))
+	_, _ = rand.Read(sealed[9 : 1+sealStampSize])
+
+	mac := hmac.New(sha256.New, []byte(c.config.AdmissionSecret))
+	mac.Write(sealed[1:])
+	mac.Write(frame)
+
+	sealed = mac.Sum(sealed)
+	return append(sealed, frame...)
+}
+
+func (c *Cluster) unseal(frame []byte) ([]byte, error) {
+	if c.config.AdmissionSecret == "" {
+		return frame, nil
+	}
+
+	if len(frame) < sealHeaderSize || frame[0] != sealedFrameMarker {
+		return nil, ErrUnauthenticatedFrame
+	}
+	stamp, body := frame[1:1+sealStampSize], frame[sealHeaderSize:]
+
+	mac := hmac.New(sha256.New, []byte(c.config.AdmissionSecret))
+	mac.Write(stamp)
+	mac.Write(body)
+	if !hmac.Equal(mac.Sum(nil), frame[1+sealStampSize:sealHeaderSize]) {
+		return nil, ErrUnauthenticatedFrame
+	}
+
+	sent := time.Unix(0, int64(binary.BigEndian.Uint64(stamp[:8])))
+	if !c.replays.first([sealStampSize]byte(stamp), sent, time.Now()) {
+		return nil, ErrReplayedFrame
+	}
+	return body, nil
+}
+
+type replayGuard struct {
+	seen   map[[sealStampSize]byte]time.Time
+	pruned time.Time
+	mu     sync.Mutex
+}
+
+func newReplayGuard() *replayGuard {
+	return &replayGuard{seen: make(map[[sealStampSize]byte]time.Time)}
+}
+
+func (g *replayGuard) first(stamp [sealStampSize]byte, sent, now time.Time) bool {
+	if age := now.Sub(sent); age > replayWindow || age < -replayWindow {
+		return false
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if now.Sub(g.pruned) > replayWindow {
+		for s, at := range g.seen {
+			if now.Sub(at) > replayWindow {
+				delete(g.seen, s)
+			}
+		}
+		g.pruned = now
+	}
+
+	if _, seen := g.seen[stamp]; seen {
+		return false
+	}
+	g.seen[stamp] = sent
+	return true
+}
+
+type tlsTransport struct {
+	*memberlist.NetTransport
+	config   *tls.Config
+	streamCh chan net.Conn
+	stopCh   chan struct{}
+	stopOnce sync.Once
+}
+
+func newTLSTransport(bindAddr string, bindPort int, config *tls.Config) (*tlsTransport, error) {
+	inner, err := memberlist.NewNetTransport(&memberlist.NetTransportConfig{
+		BindAddrs: []string{bindAddr},
+		BindPort:  bindPort,
+		Logger:    log.New(os.Stderr, "", log.LstdFlags),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transport: %w", err)
+	}
+
+	server := config.Clone()
+	server.ClientAuth = tls.RequireAndVerifyClientCert
+
+	t := &tlsTransport{
+		NetTransport: inner,
+		config:       server,
+		streamCh:     make(chan net.Conn),
+		stopCh:       make(chan struct{}),
+	}
+	go t.accept()
+
+	return t, nil
+}
+
+func (t *tlsTransport) accept() {
+	for {
+		select {
+		case <-t.stopCh:
+			return
+		case conn := <-t.NetTransport.StreamCh():
+			go t.handshake(tls.Server(conn, t.config))
+		}
+	}
+}
+
+func (t *tlsTransport) handshake(conn *tls.Conn) {
+	if err := conn.SetDeadline(time.Now().Add(tlsHandshakeTimeout)); err != nil {
+		conn.Close()
+		return
+	}
+	if err := conn.Handshake(); err != nil {
+		conn.Close()
+		return
+	}
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		conn.Close()
+		return
+	}
+
+	select {
+	case t.streamCh <- conn:
+	case <-t.stopCh:
+		conn.Close()
+	}
+}
+
+func (t *tlsTransport) DialTimeout(addr string, timeout time.Duration) (net.Conn, error) {
+	return t.DialAddressTimeout(memberlist.Address{Addr: addr}, timeout)
+}
+
+func (t *tlsTransport) DialAddressTimeout(a memberlist.Address, timeout time.Duration) (net.Conn, error) {
+	dialer := &tls.Dialer{
+		NetDialer: &net.Dialer{Timeout: timeout},
+		Config:    t.clientConfig(),
+	}
+	return dialer.Dial("tcp", a.Addr)
+}
+
+func (t *tlsTransport) clientConfig() *tls.Config {
+	client := t.config.Clone()
+	client.ClientAuth = tls.NoClientCert
+	return client
+}
+
+func (t *tlsTransport) StreamCh() <-chan net.Conn {
+	return t.streamCh
+}
+
+func (t *tlsTransport) Shutdown() error {
+	var err error
+	t.stopOnce.Do(func() {
+		err = t.NetTransport.Shutdown()
+		close(t.stopCh)
+	})
+	return err
+}
@@ -0,0 +1,232 @@
+package cluster
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/memberlist"
+	"github.com/kleeedolinux/gorilix/messaging"
+)
+
+func TestAdmissionSecretAuthenticatesFrames(t *testing.T) {
+	c := NewCluster(&ClusterConfig{NodeName: "n1", AdmissionSecret: "s3cret"}, nil)
+
+	frame, err := c.encode("n1", &messaging.Message{ID: "m-1", Payload: "hello"})
+	if err != nil {
+		t.Fatalf("encode failed: %v", err)
+	}
+	if msg, err := c.decode(frame); err != nil || msg.ID != "m-1" {
+		t.Fatalf("Expected sealed frame to decode, got %+v, %v", msg, err)
+	}
+
+	tampered := append([]byte(nil), frame...)
+	tampered[len(tampered)-2] ^= 0xff
+	if _, err := c.decode(tampered); !errors.Is(err, ErrUnauthenticatedFrame) {
+		t.Errorf("Expected ErrUnauthenticatedFrame for tampered frame, got %v", err)
+	}
+
+	plain, _ := JSONCodec{}.Encode(&messaging.Message{ID: "m-2"})
+	if _, err := c.decode(plain); !errors.Is(err, ErrUnauthenticatedFrame) {
+		t.Errorf("Expected ErrUnauthenticatedFrame for unsealed frame, got %v", err)
+	}
+
+	other := NewCluster(&ClusterConfig{NodeName: "n2", AdmissionSecret: "wrong"}, nil)
+	if _, err := other.decode(frame); !errors.Is(err, ErrUnauthenticatedFrame) {
+		t.Errorf("Expected ErrUnauthenticatedFrame with a different secret, got %v", err)
+	}
+}
+
+func TestSealedFramesCannotBeReplayed(t *testing.T) {
+	c := NewCluster(&ClusterConfig{NodeName: "n1", AdmissionSecret: "s3cret"}, nil)
+
+	frame, err := c.encode("n1", &messaging.Message{ID: "m-1"})
+	if err != nil {
+		t.Fatalf("encode failed: %v", err)
+	}
+	if _, err := c.decode(frame); err != nil {
+		t.Fatalf("Expected first delivery to decode, got %v", err)
+	}
+	if _, err := c.decode(frame); !errors.Is(err, ErrReplayedFrame) {
+		t.Errorf("Expected ErrReplayedFrame for a replayed frame, got %v", err)
+	}
+
+	again, _ := c.encode("n1", &messaging.Message{ID: "m-1"})
+	if _, err := c.decode(again); err != nil {
+		t.Errorf("Expected a resent message to get a fresh nonce, got %v", err)
+	}
+
+	now := time.Now()
+	guard := newReplayGuard()
+	if guard.first([sealStampSize]byte{1}, now.Add(-replayWindow-time.Second), now) {
+		t.Error("Expected a frame older than the replay window to be rejected")
+	}
+	if guard.first([sealStampSize]byte{2}, now.Add(replayWindow+time.Second), now) {
+		t.Error("Expected a frame from too far in the future to be rejected")
+	}
+}
+
+func TestAdmissionSecretRequiresEncryption(t *testing.T) {
+	c := NewCluster(&ClusterConfig{NodeName: "n1", BindAddr: "127.0.0.1", AdmissionSecret: "s3cret"}, nil)
+	if err := c.Start(); !errors.Is(err, ErrAdmissionWithoutEncryption) {
+		t.Errorf("Expected ErrAdmissionWithoutEncryption, got %v", err)
+	}
+}
+
+func TestAdmissionTokenWindow(t *testing.T) {
+	c := NewCluster(&ClusterConfig{NodeName: "n1", AdmissionSecret: "s3cret"}, nil)
+	peer := func(name, token string) *memberlist.Node {
+		meta := map[string]string{MetaAdmission: token}
+		return &memberlist.Node{Name: name, Meta: encodeTestMeta(meta)}
+	}
+
+	now := time.Now()
+	tests := []struct {
+		name  string
+		node  *memberlist.Node
+		admit bool
+	}{
+		{"fresh token", peer("n2", admissionToken("s3cret", "n2", now)), true},
+		{"older token replayed", peer("n2", admissionToken("s3cret", "n2", now.Add(-time.Second))), false},
+		{"refreshed token", peer("n2", admissionToken("s3cret", "n2", now.Add(time.Second))), true},
+		{"expired token", peer("n3", admissionToken("s3cret", "n3", now.Add(-admissionWindow-time.Minute))), false},
+		{"token for another node", peer("n4", admissionToken("s3cret", "n2", now)), false},
+		{"wrong secret", peer("n5", admissionToken("wrong", "n5", now)), false},
+		{"missing token", peer("n6", ""), false},
+	}
+
+	for _, tt := range tests {
+		err := c.admit(tt.node)
+		if admitted := err == nil; admitted != tt.admit {
+			t.Errorf("%s: expected admitted=%v, got %v", tt.name, tt.admit, err)
+		}
+		if err != nil && !errors.Is(err, ErrAdmissionDenied) {
+			t.Errorf("%s: expected ErrAdmissionDenied, got %v", tt.name, err)
+		}
+	}
+}
+
+func encodeTestMeta(meta map[string]string) []byte {
+	d := newClusterDelegate()
+	d.metadata = meta
+	return d.NodeMeta(memberlist.MetaMaxSize)
+}
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pool *x509.CertPool
+}
+
+func newTestCA(t *testing.T, name string) *testCA {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed to create CA: %v", err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+	return &testCA{cert: cert, key: key, pool: pool}
+}
+
+// nodeConfig issues a node certificate from ca that trusts only the cluster CA.
+func (ca *testCA) nodeConfig(t *testing.T, cluster *testCA) *tls.Config {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "node"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatalf("Failed to issue certificate: %v", err)
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}},
+		RootCAs:      cluster.pool,
+		ClientCAs:    cluster.pool,
+	}
+}
+
+func TestTLSTransportRejectsUntrustedPeers(t *testing.T) {
+	trusted, untrusted := newTestCA(t, "cluster"), newTestCA(t, "intruder")
+
+	server, err := newTLSTransport("127.0.0.1", 0, trusted.nodeConfig(t, trusted))
+	if err != nil {
+		t.Fatalf("newTLSTransport failed: %v", err)
+	}
+	defer server.Shutdown()
+	addr := fmt.Sprintf("127.0.0.1:%d", server.GetAutoBindPort())
+
+	dial := func(config *tls.Config) (net.Conn, error) {
+		client, err := newTLSTransport("127.0.0.1", 0, config)
+		if err != nil {
+			t.Fatalf("newTLSTransport failed: %v", err)
+		}
+		t.Cleanup(func() { _ = client.Shutdown() })
+		return client.DialTimeout(addr, time.Second)
+	}
+
+	conn, err := dial(untrusted.nodeConfig(t, trusted))
+	if err == nil {
+		_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+		_, err = conn.Read(make([]byte, 1))
+		conn.Close()
+	}
+	if err == nil {
+		t.Error("Expected the handshake of an untrusted peer to fail")
+	}
+	select {
+	case accepted := <-server.StreamCh():
+		accepted.Close()
+		t.Fatal("Expected the untrusted stream not to be accepted")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	conn, err = dial(trusted.nodeConfig(t, trusted))
+	if err != nil {
+		t.Fatalf("Expected a trusted peer to connect, got %v", err)
+	}
+	defer conn.Close()
+	select {
+	case accepted := <-server.StreamCh():
+		accepted.Close()
+	case <-time.After(time.Second):
+		t.Fatal("Expected the trusted stream to be accepted")
+	}
+}
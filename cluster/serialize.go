@@ -6,46 +6,60 @@ import (
 	"time"
 
 	"github.com/kleeedolinux/gorilix/messaging"
+	"github.com/kleeedolinux/gorilix/serialization"
 )
 
 
 type MessageWrapper struct {
-	ID        string            `json:"id"`
-	Type      int               `json:"type"`
-	Sender    string            `json:"sender"`
-	Receiver  string            `json:"receiver"`
-	Timestamp int64             `json:"timestamp"`
-	Headers   map[string]string `json:"headers"`
-	Payload   []byte            `json:"payload"`
+	ID          string            `json:"id"`
+	Type        int               `json:"type"`
+	Sender      string            `json:"sender"`
+	Receiver    string            `json:"receiver"`
+	Timestamp   int64             `json:"timestamp"`
+	Headers     map[string]string `json:"headers"`
+	Payload     []byte            `json:"payload"`
+	PayloadType string            `json:"payload_type,omitempty"`
+	Serializer  string            `json:"serializer,omitempty"`
+	Version     int               `json:"schema_version,omitempty"`
 }
 
 
 func SerializeMessage(msg *messaging.Message) ([]byte, error) {
 	
 	var payloadBytes []byte
+	var manifest serialization.Manifest
 	var err error
 
-	switch p := msg.Payload.(type) {
-	case []byte:
-		payloadBytes = p
-	case string:
-		payloadBytes = []byte(p)
-	default:
-		
-		payloadBytes, err = json.Marshal(p)
-		if err != nil {
-			return nil, fmt.Errorf("failed to serialize payload: %w", err)
+	if encoded, registered, err := encodeRegistered(msg.Payload); err != nil {
+		return nil, err
+	} else if registered {
+		manifest = encoded.Manifest
+		payloadBytes = encoded.Data
+	} else {
+		switch p := msg.Payload.(type) {
+		case []byte:
+			payloadBytes = p
+		case string:
+			payloadBytes = []byte(p)
+		default:
+			payloadBytes, err = json.Marshal(p)
+			if err != nil {
+				return nil, fmt.Errorf("failed to serialize payload: %w", err)
+			}
 		}
 	}
 
 	wrapper := MessageWrapper{
-		ID:        msg.ID,
-		Type:      int(msg.Type),
-		Sender:    msg.Sender,
-		Receiver:  msg.Receiver,
-		Timestamp: msg.Timestamp.UnixNano(),
-		Headers:   msg.Headers,
-		Payload:   payloadBytes,
+		ID:          msg.ID,
+		Type:        int(msg.Type),
+		Sender:      msg.Sender,
+		Receiver:    msg.Receiver,
+		Timestamp:   msg.Timestamp.UnixNano(),
+		Headers:     msg.Headers,
+		Payload:     payloadBytes,
+		PayloadType: manifest.Type,
+		Serializer:  manifest.Serializer,
+		Version:     manifest.Version,
 	}
 
 	data, err := json.Marshal(wrapper)
@@ -73,6 +87,17 @@ func DeserializeMessage(data []byte) (*messaging.Message, error) {
 		Payload:   wrapper.Payload,
 	}
 
+	if wrapper.PayloadType != "" {
+		return msg, decodeRegistered(msg, serialization.Encoded{
+			Manifest: serialization.Manifest{
+				Type:       wrapper.PayloadType,
+				Serializer: wrapper.Serializer,
+				Version:    wrapper.Version,
+			},
+			Data: wrapper.Payload,
+		})
+	}
+
 	
 	
 	if contentType, ok := wrapper.Headers["content-type"]; ok && contentType == "application/json" {
@@ -84,3 +109,33 @@ func DeserializeMessage(data []byte) (*messaging.Message, error) {
 
 	return msg, nil
 }
+
+
+func encodeRegistered(payload interface{}) (serialization.Encoded, bool, error) {
+	switch p := payload.(type) {
+	case serialization.Encoded:
+		return p, true, nil
+	case *serialization.Encoded:
+		return *p, true, nil
+	}
+
+	if _, registered := serialization.DefaultRegistry.Manifest(payload); !registered {
+		return serialization.Encoded{}, false, nil
+	}
+
+	encoded, err := serialization.DefaultRegistry.Encode(payload)
+	if err != nil {
+		return serialization.Encoded{}, false, fmt.Errorf("failed to serialize payload: %w", err)
+	}
+	return encoded, true, nil
+}
+
+func decodeRegistered(msg *messaging.Message, encoded serialization.Encoded) error {
+	payload, err := serialization.DefaultRegistry.Decode(encoded)
+	if err != nil {
+		msg.Payload = encoded
+		return fmt.Errorf("%w: %w", ErrUndecodablePayload, err)
+	}
+	msg.Payload = payload
+	return nil
+}
@@ -0,0 +1,427 @@
+package sharding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/kleeedolinux/gorilix/actor"
+	"github.com/kleeedolinux/gorilix/cluster"
+	"github.com/kleeedolinux/gorilix/messaging"
+)
+
+const (
+	KindEnvelope = "sharding-envelope"
+
+	HeaderTypeName = "gorilix-shard-type"
+	HeaderEntityID = "gorilix-shard-entity"
+	headerHops     = "gorilix-shard-hops"
+
+	DefaultShards      = 100
+	DefaultIdleTimeout = 2 * time.Minute
+	DefaultBufferSize  = 1000
+
+	rebalanceInterval = 500 * time.Millisecond
+	drainTimeout      = 5 * time.Second
+	deliverTimeout    = 5 * time.Second
+	maxHops           = 3
+)
+
+var (
+	ErrAlreadyStarted = errors.New("sharding type already started")
+
+	ErrUnknownType = errors.New("sharding type not started")
+
+	ErrInvalidProps = errors.New("sharding props require New")
+
+	ErrBufferFull = errors.New("sharding buffer full")
+)
+
+type Props struct {
+	Role        string
+	Shards      int
+	IdleTimeout time.Duration
+	BufferSize  int
+	New         func(entityID string) (actor.Actor, error)
+}
+
+type envelope struct {
+	entityID string
+	msg      messaging.Message
+}
+
+type entity struct {
+	actor      actor.Actor
+	ref        actor.ActorRef
+	lastActive time.Time
+	stopping   bool
+	buffer     []messaging.Message
+}
+
+type Sharding struct {
+	cluster *cluster.Cluster
+	regions map[string]*Region
+	wake    chan struct{}
+	mu      sync.RWMutex
+}
+
+func NewSharding(c *cluster.Cluster) *Sharding {
+	s := &Sharding{
+		cluster: c,
+		regions: make(map[string]*Region),
+		wake:    make(chan struct{}, 1),
+	}
+
+	c.HandleKind(KindEnvelope, s.handleEnvelope)
+	c.OnNodeEvent(func(cluster.NodeEvent) { s.trigger() })
+	c.OnLeave(s.passivateAll)
+	c.OnStart(func() { go s.run() })
+
+	return s
+}
+
+func (s *Sharding) Start(typeName string, props Props) (*Region, error) {
+	if props.New == nil {
+		return nil, ErrInvalidProps
+	}
+	if props.Shards <= 0 {
+		props.Shards = DefaultShards
+	}
+	if props.IdleTimeout == 0 {
+		props.IdleTimeout = DefaultIdleTimeout
+	}
+	if props.BufferSize <= 0 {
+		props.BufferSize = DefaultBufferSize
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.regions[typeName]; exists {
+		return nil, fmt.Errorf("%w: %s", ErrAlreadyStarted, typeName)
+	}
+
+	region := &Region{
+		sharding: s,
+		typeName: typeName,
+		props:    props,
+		entities: make(map[string]*entity),
+	}
+	s.regions[typeName] = region
+	return region, nil
+}
+
+func (s *Sharding) Region(typeName string) (*Region, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	region, exists := s.regions[typeName]
+	return region, exists
+}
+
+func (s *Sharding) handleEnvelope(ctx context.Context, msg *messaging.Message) error {
+	region, exists := s.Region(msg.Headers[HeaderTypeName])
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrUnknownType, msg.Headers[HeaderTypeName])
+	}
+
+	entityID := msg.Headers[HeaderEntityID]
+	hops, _ := strconv.Atoi(msg.Headers[headerHops])
+
+	out := *msg
+	out.Headers = make(map[string]string, len(msg.Headers))
+	for k, v := range msg.Headers {
+		if k != cluster.HeaderKind && k != HeaderTypeName && k != HeaderEntityID && k != headerHops {
+			out.Headers[k] = v
+		}
+	}
+
+	return region.route(ctx, entityID, out, hops)
+}
+
+func (s *Sharding) regionList() []*Region {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	regions := make([]*Region, 0, len(s.regions))
+	for _, region := range s.regions {
+		regions = append(regions, region)
+	}
+	return regions
+}
+
+func (s *Sharding) passivateAll() {
+	for _, region := range s.regionList() {
+		for _, entityID := range region.LocalEntities() {
+			region.passivate(entityID)
+		}
+	}
+}
+
+func (s *Sharding) run() {
+	ticker := time.NewTicker(rebalanceInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.cluster.Done():
+			return
+		case <-ticker.C:
+		case <-s.wake:
+		}
+
+		for _, region := range s.regionList() {
+			region.rebalance()
+		}
+	}
+}
+
+func (s *Sharding) trigger() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+type Region struct {
+	sharding *Sharding
+	typeName string
+	props    Props
+	entities map[string]*entity
+	buffer   []envelope
+	mu       sync.Mutex
+}
+
+func (r *Region) TypeName() string {
+	return r.typeName
+}
+
+func (r *Region) Send(ctx context.Context, entityID string, message interface{}) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	msg, ok := message.(messaging.Message)
+	if !ok {
+		msg = messaging.Message{
+			Type:    messaging.Normal,
+			Payload: message,
+			Headers: map[string]string{cluster.HeaderRawPayload: "true"},
+		}
+	}
+	return r.route(ctx, entityID, msg, 0)
+}
+
+func (r *Region) Ref(entityID string) actor.ActorRef {
+	return &entityRef{region: r, entityID: entityID}
+}
+
+func (r *Region) ShardOf(entityID string) int {
+	h := fnv.New32a()
+	h.Write([]byte(entityID))
+	return int(h.Sum32() % uint32(r.props.Shards))
+}
+
+// Owner uses rendezvous hashing, so only the shards of joining or leaving nodes move.
+func (r *Region) Owner(shard int) (string, bool) {
+	var owner string
+	var best uint64
+	for _, node := range r.sharding.cluster.Members() {
+		if r.props.Role != "" && !node.HasRole(r.props.Role) {
+			continue
+		}
+		if node.Status != cluster.NodeAlive && node.Status != cluster.NodeSuspect {
+			continue
+		}
+
+		h := fnv.New64a()
+		h.Write([]byte(r.typeName + "/" + strconv.Itoa(shard) + "/" + node.Name))
+		if weight := h.Sum64(); owner == "" || weight > best || weight == best && node.Name < owner {
+			owner, best = node.Name, weight
+		}
+	}
+	return owner, owner != ""
+}
+
+func (r *Region) LocalEntities() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ids := make([]string, 0, len(r.entities))
+	for id := range r.entities {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func (r *Region) LocalShards() []int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	seen := make(map[int]bool)
+	var shards []int
+	for id := range r.entities {
+		if shard := r.ShardOf(id); !seen[shard] {
+			seen[shard] = true
+			shards = append(shards, shard)
+		}
+	}
+	sort.Ints(shards)
+	return shards
+}
+
+func (r *Region) route(ctx context.Context, entityID string, msg messaging.Message, hops int) error {
+	owner, ok := r.Owner(r.ShardOf(entityID))
+	if !ok || hops >= maxHops {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		return r.bufferLocked(entityID, msg)
+	}
+
+	if owner == r.sharding.cluster.NodeName() {
+		return r.deliver(ctx, entityID, msg)
+	}
+
+	if err := r.forward(owner, entityID, msg, hops); err != nil {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		return r.bufferLocked(entityID, msg)
+	}
+	return nil
+}
+
+func (r *Region) forward(owner, entityID string, msg messaging.Message, hops int) error {
+	out := msg
+	out.Headers = make(map[string]string, len(msg.Headers)+4)
+	for k, v := range msg.Headers {
+		out.Headers[k] = v
+	}
+	out.Headers[cluster.HeaderKind] = KindEnvelope
+	out.Headers[HeaderTypeName] = r.typeName
+	out.Headers[HeaderEntityID] = entityID
+	out.Headers[headerHops] = strconv.Itoa(hops + 1)
+
+	return r.sharding.cluster.Send(owner, &out)
+}
+
+func (r *Region) bufferLocked(entityID string, msg messaging.Message) error {
+	if len(r.buffer) >= r.props.BufferSize {
+		return fmt.Errorf("%w: %s", ErrBufferFull, r.typeName)
+	}
+	r.buffer = append(r.buffer, envelope{entityID: entityID, msg: msg})
+	return nil
+}
+
+func (r *Region) deliver(ctx context.Context, entityID string, msg messaging.Message) error {
+	r.mu.Lock()
+	e, exists := r.entities[entityID]
+	if exists && e.stopping {
+		defer r.mu.Unlock()
+		if len(e.buffer) >= r.props.BufferSize {
+			return fmt.Errorf("%w: %s", ErrBufferFull, r.typeName)
+		}
+		e.buffer = append(e.buffer, msg)
+		return nil
+	}
+
+	if !exists || !e.actor.IsRunning() {
+		a, err := r.props.New(entityID)
+		if err != nil {
+			r.mu.Unlock()
+			return fmt.Errorf("failed to start entity %s/%s: %w", r.typeName, entityID, err)
+		}
+		e = &entity{actor: a, ref: actor.NewActorRef(a)}
+		r.entities[entityID] = e
+	}
+	e.lastActive = time.Now()
+	ref := e.ref
+	r.mu.Unlock()
+
+	if msg.Headers[cluster.HeaderRawPayload] == "true" {
+		return ref.Send(ctx, msg.Payload)
+	}
+	return ref.Send(ctx, msg)
+}
+
+func (r *Region) passivate(entityID string) {
+	r.mu.Lock()
+	e, exists := r.entities[entityID]
+	if !exists || e.stopping {
+		r.mu.Unlock()
+		return
+	}
+	e.stopping = true
+	r.mu.Unlock()
+
+	if drainer, ok := e.actor.(actor.Drainer); ok {
+		ctx, cancel := context.WithTimeout(context.Background(), drainTimeout)
+		_ = drainer.Drain(ctx)
+		cancel()
+	}
+	_ = e.actor.Stop()
+
+	r.mu.Lock()
+	delete(r.entities, entityID)
+	pending := e.buffer
+	r.mu.Unlock()
+
+	for _, msg := range pending {
+		ctx, cancel := context.WithTimeout(context.Background(), deliverTimeout)
+		_ = r.route(ctx, entityID, msg, 0)
+		cancel()
+	}
+}
+
+func (r *Region) rebalance() {
+	self := r.sharding.cluster.NodeName()
+
+	r.mu.Lock()
+	var moved, idle []string
+	for id, e := range r.entities {
+		if e.stopping {
+			continue
+		}
+		if owner, ok := r.Owner(r.ShardOf(id)); ok && owner != self {
+			moved = append(moved, id)
+		} else if r.props.IdleTimeout > 0 && time.Since(e.lastActive) >= r.props.IdleTimeout {
+			idle = append(idle, id)
+		}
+	}
+	pending := r.buffer
+	r.buffer = nil
+	r.mu.Unlock()
+
+	for _, id := range append(moved, idle...) {
+		r.passivate(id)
+	}
+
+	for _, env := range pending {
+		ctx, cancel := context.WithTimeout(context.Background(), deliverTimeout)
+		_ = r.route(ctx, env.entityID, env.msg, 0)
+		cancel()
+	}
+}
+
+type entityRef struct {
+	region   *Region
+	entityID string
+}
+
+func (r *entityRef) Send(ctx context.Context, message interface{}) error {
+	return r.region.Send(ctx, r.entityID, message)
+}
+
+func (r *entityRef) ID() string {
+	return r.region.typeName + "/" + r.entityID
+}
+
+func (r *entityRef) IsRunning() bool {
+	_, ok := r.region.Owner(r.region.ShardOf(r.entityID))
+	return ok
+}
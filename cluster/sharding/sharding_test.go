@@ -0,0 +1,157 @@
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kleeedolinux/gorilix/actor"
+	"github.com/kleeedolinux/gorilix/cluster"
+	"github.com/kleeedolinux/gorilix/cluster/internal/clustertest"
+	"github.com/kleeedolinux/gorilix/messaging"
+)
+
+func newNode(t *testing.T, name string) (*cluster.Cluster, *Sharding) {
+	t.Helper()
+
+	c := cluster.NewCluster(clustertest.Config(name), nil)
+	s := NewSharding(c)
+	clustertest.Start(t, c)
+	return c, s
+}
+
+func localMessage(payload string) messaging.Message {
+	return messaging.Message{Payload: payload, Headers: map[string]string{cluster.HeaderRawPayload: "true"}}
+}
+
+func nopProps() Props {
+	return Props{New: func(entityID string) (actor.Actor, error) {
+		return actor.NewActor(entityID, func(ctx context.Context, msg interface{}) error { return nil }, 10), nil
+	}}
+}
+
+func TestOwnerOnlyMovesShardsOfJoiningNodes(t *testing.T) {
+	c1, s1 := newNode(t, "n1")
+	c2, _ := newNode(t, "n2")
+	clustertest.Join(t, c2, c1)
+
+	region, err := s1.Start("carts", nopProps())
+	if err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	clustertest.WaitFor(t, "n1 to see two members", func() bool { return clustertest.Alive(c1) == 2 })
+
+	before := make(map[int]string, DefaultShards)
+	for shard := 0; shard < DefaultShards; shard++ {
+		owner, ok := region.Owner(shard)
+		if !ok {
+			t.Fatalf("Expected shard %d to have an owner", shard)
+		}
+		before[shard] = owner
+	}
+
+	c3, _ := newNode(t, "n3")
+	clustertest.Join(t, c3, c1)
+	clustertest.WaitFor(t, "n1 to see three members", func() bool { return clustertest.Alive(c1) == 3 })
+
+	moved := 0
+	for shard, previous := range before {
+		owner, _ := region.Owner(shard)
+		if owner == previous {
+			continue
+		}
+		if owner != "n3" {
+			t.Errorf("Shard %d moved from %s to %s instead of the joining node", shard, previous, owner)
+		}
+		moved++
+	}
+	if moved == 0 || moved == DefaultShards {
+		t.Errorf("Expected some but not all shards to move to n3, moved %d", moved)
+	}
+}
+
+func TestPassivationRebuffersMessages(t *testing.T) {
+	_, s := newNode(t, "n1")
+
+	release := make(chan struct{})
+	received := make(chan string, 10)
+	var started atomic.Int32
+	region, err := s.Start("carts", Props{New: func(entityID string) (actor.Actor, error) {
+		incarnation := started.Add(1)
+		return actor.NewActor(entityID, func(ctx context.Context, msg interface{}) error {
+			if msg == "slow" {
+				<-release
+			}
+			received <- fmt.Sprintf("%d:%v", incarnation, msg)
+			return nil
+		}, 10), nil
+	}})
+	if err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	ctx := context.Background()
+	if err := region.deliver(ctx, "cart-1", localMessage("slow")); err != nil {
+		t.Fatalf("deliver failed: %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		region.passivate("cart-1")
+		close(done)
+	}()
+	clustertest.WaitFor(t, "cart-1 to start stopping", func() bool {
+		region.mu.Lock()
+		defer region.mu.Unlock()
+		return region.entities["cart-1"].stopping
+	})
+
+	if err := region.deliver(ctx, "cart-1", localMessage("late")); err != nil {
+		t.Fatalf("deliver failed: %v", err)
+	}
+	close(release)
+	<-done
+
+	for _, want := range []string{"1:slow", "2:late"} {
+		select {
+		case got := <-received:
+			if got != want {
+				t.Errorf("Expected %q, got %q", want, got)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("Expected %q to arrive", want)
+		}
+	}
+}
+
+func TestIdleEntitiesArePassivated(t *testing.T) {
+	_, s := newNode(t, "n1")
+
+	var entity actor.Actor
+	props := nopProps()
+	newEntity := props.New
+	props.New = func(entityID string) (actor.Actor, error) {
+		a, err := newEntity(entityID)
+		entity = a
+		return a, err
+	}
+	props.IdleTimeout = 50 * time.Millisecond
+
+	region, err := s.Start("carts", props)
+	if err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	if err := region.deliver(context.Background(), "cart-1", localMessage("hello")); err != nil {
+		t.Fatalf("deliver failed: %v", err)
+	}
+	if entity == nil || !entity.IsRunning() {
+		t.Fatal("Expected cart-1 to be running")
+	}
+
+	clustertest.WaitFor(t, "cart-1 to be passivated", func() bool { return len(region.LocalEntities()) == 0 })
+	if entity.IsRunning() {
+		t.Error("Expected the idle entity to be stopped")
+	}
+}
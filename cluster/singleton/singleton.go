@@ -0,0 +1,439 @@
+package singleton
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/kleeedolinux/gorilix/actor"
+	"github.com/kleeedolinux/gorilix/cluster"
+	"github.com/kleeedolinux/gorilix/messaging"
+)
+
+const (
+	KindMessage = "singleton-message"
+	KindHandOff = "singleton-handoff"
+
+	HeaderSingleton = "gorilix-singleton"
+	headerHops      = "gorilix-singleton-hops"
+
+	DefaultBufferSize = 1000
+
+	checkInterval   = 500 * time.Millisecond
+	maxStartBackoff = 30 * time.Second
+	settleDelay     = time.Second
+	handOffTimeout  = 5 * time.Second
+	deliverTimeout  = 5 * time.Second
+	maxHops         = 3
+)
+
+var (
+	ErrAlreadyRegistered = errors.New("singleton already registered")
+
+	ErrNotRegistered = errors.New("singleton not registered")
+
+	ErrInvalidProps = errors.New("singleton props require Start and Stop")
+
+	ErrBufferFull = errors.New("singleton buffer full")
+)
+
+type Props struct {
+	Role       string
+	BufferSize int
+	Start      func(state []byte) (actor.ActorRef, error)
+	Stop       func(ref actor.ActorRef) ([]byte, error)
+}
+
+type handOff struct {
+	Name  string `json:"name"`
+	State []byte `json:"state"`
+}
+
+type singleton struct {
+	name      string
+	props     Props
+	instance  actor.ActorRef
+	state     []byte
+	handedOff bool
+	buffer    []messaging.Message
+	failures  int
+	retryAt   time.Time
+}
+
+type Manager struct {
+	cluster    *cluster.Cluster
+	singletons map[string]*singleton
+	created    time.Time
+	wake       chan struct{}
+	mu         sync.Mutex
+}
+
+func NewManager(c *cluster.Cluster) *Manager {
+	m := &Manager{
+		cluster:    c,
+		singletons: make(map[string]*singleton),
+		created:    time.Now(),
+		wake:       make(chan struct{}, 1),
+	}
+
+	c.HandleKind(KindMessage, m.handleMessage)
+	c.HandleRequest(KindHandOff, m.handleHandOff)
+	c.OnNodeEvent(func(cluster.NodeEvent) { m.trigger() })
+	c.OnLeave(m.handOffAll)
+	c.OnStart(func() { go m.run() })
+
+	return m
+}
+
+func (m *Manager) Register(name string, props Props) error {
+	if props.Start == nil || props.Stop == nil {
+		return ErrInvalidProps
+	}
+	if props.BufferSize <= 0 {
+		props.BufferSize = DefaultBufferSize
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.singletons[name]; exists {
+		return fmt.Errorf("%w: %s", ErrAlreadyRegistered, name)
+	}
+	m.singletons[name] = &singleton{name: name, props: props}
+	m.trigger()
+	return nil
+}
+
+func (m *Manager) Proxy(name string) (actor.ActorRef, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.singletons[name]; !exists {
+		return nil, fmt.Errorf("%w: %s", ErrNotRegistered, name)
+	}
+	return &Proxy{manager: m, name: name}, nil
+}
+
+func (m *Manager) Host(name string) (string, bool) {
+	m.mu.Lock()
+	s, exists := m.singletons[name]
+	m.mu.Unlock()
+
+	if !exists {
+		return "", false
+	}
+	host, _ := m.host(s.props.Role)
+	return host, host != ""
+}
+
+type Proxy struct {
+	manager *Manager
+	name    string
+}
+
+func (p *Proxy) Send(ctx context.Context, message interface{}) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	msg, ok := message.(messaging.Message)
+	if !ok {
+		msg = messaging.Message{
+			Type:    messaging.Normal,
+			Payload: message,
+			Headers: map[string]string{cluster.HeaderRawPayload: "true"},
+		}
+	}
+	return p.manager.route(ctx, p.name, msg, 0)
+}
+
+func (p *Proxy) ID() string {
+	return "singleton:" + p.name
+}
+
+func (p *Proxy) IsRunning() bool {
+	_, ok := p.manager.Host(p.name)
+	return ok
+}
+
+func (m *Manager) route(ctx context.Context, name string, msg messaging.Message, hops int) error {
+	m.mu.Lock()
+	s, exists := m.singletons[name]
+	if !exists {
+		m.mu.Unlock()
+		return fmt.Errorf("%w: %s", ErrNotRegistered, name)
+	}
+
+	host, _ := m.host(s.props.Role)
+	instance := s.instance
+	if host == "" || hops >= maxHops || host == m.cluster.NodeName() && instance == nil {
+		err := m.bufferLocked(s, msg)
+		m.mu.Unlock()
+		return err
+	}
+	m.mu.Unlock()
+
+	if host == m.cluster.NodeName() {
+		return deliver(ctx, instance, msg)
+	}
+
+	if err := m.forward(host, name, msg, hops); err != nil {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		return m.bufferLocked(s, msg)
+	}
+	return nil
+}
+
+func (m *Manager) forward(host, name string, msg messaging.Message, hops int) error {
+	out := msg
+	out.Headers = make(map[string]string, len(msg.Headers)+3)
+	for k, v := range msg.Headers {
+		out.Headers[k] = v
+	}
+	out.Headers[cluster.HeaderKind] = KindMessage
+	out.Headers[HeaderSingleton] = name
+	out.Headers[headerHops] = strconv.Itoa(hops + 1)
+
+	return m.cluster.Send(host, &out)
+}
+
+func (m *Manager) bufferLocked(s *singleton, msg messaging.Message) error {
+	if len(s.buffer) >= s.props.BufferSize {
+		return fmt.Errorf("%w: %s", ErrBufferFull, s.name)
+	}
+	s.buffer = append(s.buffer, msg)
+	return nil
+}
+
+func (m *Manager) handleMessage(ctx context.Context, msg *messaging.Message) error {
+	name := msg.Headers[HeaderSingleton]
+	hops, _ := strconv.Atoi(msg.Headers[headerHops])
+
+	out := *msg
+	out.Headers = make(map[string]string, len(msg.Headers))
+	for k, v := range msg.Headers {
+		if k != cluster.HeaderKind && k != HeaderSingleton && k != headerHops {
+			out.Headers[k] = v
+		}
+	}
+
+	return m.route(ctx, name, out, hops)
+}
+
+func (m *Manager) handleHandOff(ctx context.Context, msg *messaging.Message) (interface{}, error) {
+	var h handOff
+	if err := cluster.DecodePayload(msg, &h); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, exists := m.singletons[h.Name]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrNotRegistered, h.Name)
+	}
+	s.state = h.State
+	s.handedOff = true
+	m.trigger()
+	return nil, nil
+}
+
+func (m *Manager) handOffAll() {
+	m.mu.Lock()
+	var running []*singleton
+	for _, s := range m.singletons {
+		if s.instance != nil {
+			running = append(running, s)
+		}
+	}
+	m.mu.Unlock()
+
+	for _, s := range running {
+		m.handOff(s)
+	}
+}
+
+func (m *Manager) handOff(s *singleton) {
+	m.mu.Lock()
+	instance := s.instance
+	s.instance = nil
+	m.mu.Unlock()
+
+	state, err := s.props.Stop(instance)
+	if err != nil {
+		state = nil
+	}
+
+	next, _ := m.host(s.props.Role)
+	if next == "" || next == m.cluster.NodeName() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), handOffTimeout)
+	_, _ = m.cluster.Request(ctx, next, KindHandOff, handOff{Name: s.name, State: state})
+	cancel()
+
+	m.flush(s, next)
+}
+
+func (m *Manager) run() {
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.cluster.Done():
+			return
+		case <-ticker.C:
+		case <-m.wake:
+		}
+		m.check()
+	}
+}
+
+func (m *Manager) trigger() {
+	select {
+	case m.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (m *Manager) check() {
+	if time.Since(m.created) < settleDelay {
+		return
+	}
+
+	m.mu.Lock()
+	singletons := make([]*singleton, 0, len(m.singletons))
+	for _, s := range m.singletons {
+		singletons = append(singletons, s)
+	}
+	m.mu.Unlock()
+
+	self := m.cluster.NodeName()
+	for _, s := range singletons {
+		host, waiting := m.host(s.props.Role)
+		switch {
+		case host == "":
+		case host != self:
+			m.flush(s, host)
+		default:
+			m.mu.Lock()
+			running := s.instance != nil && s.instance.IsRunning()
+			handedOff := s.handedOff
+			backingOff := time.Now().Before(s.retryAt)
+			m.mu.Unlock()
+
+			if !running && !backingOff && (!waiting || handedOff) {
+				m.start(s)
+			}
+		}
+	}
+}
+
+func (m *Manager) start(s *singleton) {
+	m.mu.Lock()
+	state := s.state
+	m.mu.Unlock()
+
+	ref, err := s.props.Start(state)
+	if err != nil {
+		m.mu.Lock()
+		s.failures++
+		s.retryAt = time.Now().Add(startBackoff(s.failures))
+		m.mu.Unlock()
+		return
+	}
+
+	for {
+		m.mu.Lock()
+		pending := s.buffer
+		s.buffer = nil
+		if len(pending) == 0 {
+			s.instance = ref
+			s.state = nil
+			s.handedOff = false
+			s.failures = 0
+			s.retryAt = time.Time{}
+			m.mu.Unlock()
+			return
+		}
+		m.mu.Unlock()
+
+		for _, msg := range pending {
+			ctx, cancel := context.WithTimeout(context.Background(), deliverTimeout)
+			_ = deliver(ctx, ref, msg)
+			cancel()
+		}
+	}
+}
+
+func (m *Manager) flush(s *singleton, host string) {
+	m.mu.Lock()
+	pending := s.buffer
+	s.buffer = nil
+	m.mu.Unlock()
+
+	for i, msg := range pending {
+		if err := m.forward(host, s.name, msg, 0); err != nil {
+			m.mu.Lock()
+			s.buffer = append(pending[i:], s.buffer...)
+			m.mu.Unlock()
+			return
+		}
+	}
+}
+
+// host is the oldest member that is not leaving; waiting means an older one still is.
+func (m *Manager) host(role string) (host string, waiting bool) {
+	var candidates []*cluster.Node
+	for _, node := range m.cluster.Members() {
+		if role != "" && !node.HasRole(role) {
+			continue
+		}
+		if node.Status == cluster.NodeAlive || node.Status == cluster.NodeSuspect ||
+			node.Status == cluster.NodeJoining || node.Status == cluster.NodeLeaving {
+			candidates = append(candidates, node)
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		ti, tj := candidates[i].StartedAt(), candidates[j].StartedAt()
+		if !ti.Equal(tj) {
+			return ti.Before(tj)
+		}
+		return candidates[i].Name < candidates[j].Name
+	})
+
+	for _, node := range candidates {
+		if node.Status != cluster.NodeLeaving {
+			return node.Name, waiting
+		}
+		waiting = true
+	}
+	return "", waiting
+}
+
+func startBackoff(failures int) time.Duration {
+	backoff := checkInterval
+	for i := 1; i < failures && backoff < maxStartBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxStartBackoff {
+		backoff = maxStartBackoff
+	}
+	return backoff
+}
+
+func deliver(ctx context.Context, ref actor.ActorRef, msg messaging.Message) error {
+	if msg.Headers[cluster.HeaderRawPayload] == "true" {
+		return ref.Send(ctx, msg.Payload)
+	}
+	return ref.Send(ctx, msg)
+}
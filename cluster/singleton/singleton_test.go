@@ -0,0 +1,171 @@
+package singleton
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kleeedolinux/gorilix/actor"
+	"github.com/kleeedolinux/gorilix/cluster"
+	"github.com/kleeedolinux/gorilix/cluster/internal/clustertest"
+	"github.com/kleeedolinux/gorilix/messaging"
+)
+
+func newNode(t *testing.T, name string) (*cluster.Cluster, *Manager) {
+	t.Helper()
+
+	c := cluster.NewCluster(clustertest.Config(name), nil)
+	m := NewManager(c)
+	clustertest.Start(t, c)
+	return c, m
+}
+
+func TestHandOffMovesStateAndBufferedMessages(t *testing.T) {
+	c1, m1 := newNode(t, "n1")
+	c2, m2 := newNode(t, "n2")
+	clustertest.Join(t, c2, c1)
+
+	first, second := clustertest.NewRecorder("first"), clustertest.NewRecorder("second")
+	handedOver := make(chan string, 1)
+	stopping, release := make(chan struct{}), make(chan struct{})
+
+	err := m1.Register("counter", Props{
+		Start: func(state []byte) (actor.ActorRef, error) { return first, nil },
+		Stop: func(ref actor.ActorRef) ([]byte, error) {
+			close(stopping)
+			<-release
+			first.Stop()
+			return []byte("42"), nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	err = m2.Register("counter", Props{
+		Start: func(state []byte) (actor.ActorRef, error) {
+			handedOver <- string(state)
+			return second, nil
+		},
+		Stop: func(ref actor.ActorRef) ([]byte, error) { return nil, nil },
+	})
+	if err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	proxy, err := m2.Proxy("counter")
+	if err != nil {
+		t.Fatalf("Proxy failed: %v", err)
+	}
+
+	clustertest.WaitFor(t, "n1 to host the singleton", func() bool {
+		host, _ := m2.Host("counter")
+		return host == "n1" && first.IsRunning()
+	})
+	if err := proxy.Send(context.Background(), "before"); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	first.Expect(t, "before")
+
+	go func() { _ = c1.Leave(5 * time.Second) }()
+	<-stopping
+
+	clustertest.WaitFor(t, "n2 to see n1 leaving", func() bool {
+		host, _ := m2.Host("counter")
+		return host == "n2"
+	})
+	if err := proxy.Send(context.Background(), "during"); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	select {
+	case state := <-handedOver:
+		t.Fatalf("Expected n2 to wait for the hand-off, started with %q", state)
+	default:
+	}
+	close(release)
+
+	select {
+	case state := <-handedOver:
+		if state != "42" {
+			t.Errorf("Expected handed-over state 42, got %q", state)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected n2 to start the singleton after the hand-off")
+	}
+	second.Expect(t, "during")
+}
+
+func TestRouteBuffersAfterMaxHops(t *testing.T) {
+	c1, m1 := newNode(t, "n1")
+	c2, m2 := newNode(t, "n2")
+	clustertest.Join(t, c2, c1)
+
+	host := clustertest.NewRecorder("host")
+	props := Props{
+		Start: func(state []byte) (actor.ActorRef, error) { return host, nil },
+		Stop:  func(ref actor.ActorRef) ([]byte, error) { return nil, nil },
+	}
+	if err := m1.Register("counter", props); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	props.BufferSize = 1
+	if err := m2.Register("counter", props); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	clustertest.WaitFor(t, "n1 to host the singleton", func() bool {
+		h, _ := m2.Host("counter")
+		return h == "n1" && host.IsRunning()
+	})
+
+	msg := messaging.Message{Payload: "looped", Headers: map[string]string{cluster.HeaderRawPayload: "true"}}
+	if err := m2.route(context.Background(), "counter", msg, maxHops); err != nil {
+		t.Fatalf("route failed: %v", err)
+	}
+	if err := m2.route(context.Background(), "counter", msg, maxHops); !errors.Is(err, ErrBufferFull) {
+		t.Errorf("Expected ErrBufferFull, got %v", err)
+	}
+
+	host.Expect(t, "looped")
+}
+
+func TestStartBacksOff(t *testing.T) {
+	m := NewManager(cluster.NewCluster(&cluster.ClusterConfig{NodeName: "n1"}, nil))
+
+	fail := true
+	ref := clustertest.NewRecorder("instance")
+	err := m.Register("counter", Props{
+		Start: func(state []byte) (actor.ActorRef, error) {
+			if fail {
+				return nil, errors.New("boom")
+			}
+			return ref, nil
+		},
+		Stop: func(ref actor.ActorRef) ([]byte, error) { return nil, nil },
+	})
+	if err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	s := m.singletons["counter"]
+
+	for failures, want := range []time.Duration{checkInterval, 2 * checkInterval, 4 * checkInterval} {
+		before := time.Now()
+		m.start(s)
+		if s.failures != failures+1 {
+			t.Fatalf("Expected %d failures, got %d", failures+1, s.failures)
+		}
+		if wait := s.retryAt.Sub(before); wait < want || wait > want+time.Second {
+			t.Errorf("Expected retry after %v, got %v", want, wait)
+		}
+	}
+
+	if got := startBackoff(100); got != maxStartBackoff {
+		t.Errorf("Expected backoff capped at %v, got %v", maxStartBackoff, got)
+	}
+
+	fail = false
+	m.start(s)
+	if s.instance != ref || s.failures != 0 || !s.retryAt.IsZero() {
+		t.Error("Expected a successful start to reset the backoff")
+	}
+}
@@ -0,0 +1,185 @@
+package cluster
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+type SplitBrainStrategy string
+
+const (
+	KeepMajority SplitBrainStrategy = "keep-majority"
+	KeepOldest   SplitBrainStrategy = "keep-oldest"
+	KeepReferee  SplitBrainStrategy = "keep-referee"
+	StaticQuorum SplitBrainStrategy = "static-quorum"
+
+	defaultStableAfter = 20 * time.Second
+)
+
+type SplitBrainConfig struct {
+	Strategy    SplitBrainStrategy
+	StableAfter time.Duration
+	Role        string
+	Referee     string
+	QuorumSize  int
+	DownIfAlone bool
+	OnDown      func()
+}
+
+type splitBrainDecision int
+
+const (
+	downUnreachable splitBrainDecision = iota
+	downReachable
+)
+
+func (s *SplitBrainConfig) validate() error {
+	switch s.Strategy {
+	case KeepMajority, KeepOldest:
+	case KeepReferee:
+		if s.Referee == "" {
+			return fmt.Errorf("%w: keep-referee requires a referee node", ErrInvalidSplitBrainConfig)
+		}
+	case StaticQuorum:
+		if s.QuorumSize <= 0 {
+			return fmt.Errorf("%w: static-quorum requires a quorum size", ErrInvalidSplitBrainConfig)
+		}
+	default:
+		return fmt.Errorf("%w: unknown strategy %q", ErrInvalidSplitBrainConfig, s.Strategy)
+	}
+	return nil
+}
+
+// decide picks the surviving side; reachable includes this node, unreachable holds the suspect members.
+func (s *SplitBrainConfig) decide(reachable, unreachable []*Node) splitBrainDecision {
+	counted := func(nodes []*Node) []*Node {
+		if s.Role == "" {
+			return nodes
+		}
+		var filtered []*Node
+		for _, node := range nodes {
+			if node.HasRole(s.Role) {
+				filtered = append(filtered, node)
+			}
+		}
+		return filtered
+	}
+	ours, theirs := counted(reachable), counted(unreachable)
+
+	keep := func(survive bool) splitBrainDecision {
+		if survive {
+			return downUnreachable
+		}
+		return downReachable
+	}
+
+	switch s.Strategy {
+	case KeepMajority:
+		if len(ours) != len(theirs) {
+			return keep(len(ours) > len(theirs))
+		}
+		if len(ours) == 0 {
+			ours, theirs = reachable, unreachable
+		}
+		return keep(lowestName(ours) < lowestName(theirs))
+
+	case KeepOldest:
+		all := append(append([]*Node(nil), ours...), theirs...)
+		if len(all) == 0 {
+			return downUnreachable
+		}
+		sort.Slice(all, func(i, j int) bool {
+			ti, tj := all[i].StartedAt(), all[j].StartedAt()
+			if !ti.Equal(tj) {
+				return ti.Before(tj)
+			}
+			return all[i].Name < all[j].Name
+		})
+
+		oldestIsOurs := false
+		for _, node := range ours {
+			if node.Name == all[0].Name {
+				oldestIsOurs = true
+			}
+		}
+		if s.DownIfAlone {
+			if oldestIsOurs && len(ours) == 1 && len(theirs) > 0 {
+				return downReachable
+			}
+			if !oldestIsOurs && len(theirs) == 1 && len(ours) > 0 {
+				return downUnreachable
+			}
+		}
+		return keep(oldestIsOurs)
+
+	case KeepReferee:
+		for _, node := range reachable {
+			if node.Name == s.Referee {
+				return downUnreachable
+			}
+		}
+		return downReachable
+
+	case StaticQuorum:
+		return keep(len(ours) >= s.QuorumSize)
+	}
+	return downUnreachable
+}
+
+func (c *Cluster) resolveSplitBrain() {
+	if c.splitBrainDowned.Load() {
+		return
+	}
+	if time.Since(time.Unix(0, c.membersChangedAt.Load())) < c.config.SplitBrain.StableAfter {
+		return
+	}
+
+	var reachable, unreachable []*Node
+	c.nodesMutex.RLock()
+	for _, node := range c.nodes {
+		switch node.Status {
+		case NodeSuspect:
+			member := *node
+			unreachable = append(unreachable, &member)
+		case NodeAlive, NodeJoining:
+			member := *node
+			reachable = append(reachable, &member)
+		}
+	}
+	c.nodesMutex.RUnlock()
+
+	if len(unreachable) == 0 {
+		return
+	}
+
+	if c.config.SplitBrain.decide(reachable, unreachable) == downUnreachable {
+		var change lifecycleChange
+		c.nodesMutex.Lock()
+		for _, suspect := range unreachable {
+			if node, exists := c.nodes[suspect.Name]; exists && node.Status == NodeSuspect {
+				c.markDown(node, &change)
+			}
+		}
+		c.nodesMutex.Unlock()
+		c.applyLifecycle(change)
+		return
+	}
+
+	c.splitBrainDowned.Store(true)
+	if onDown := c.config.SplitBrain.OnDown; onDown != nil {
+		go onDown()
+	} else {
+		go c.Stop()
+	}
+}
+
+func lowestName(nodes []*Node) string {
+	lowest := ""
+	for _, node := range nodes {
+		if lowest == "" || node.Name < lowest {
+			lowest = node.Name
+		}
+	}
+	return lowest
+}
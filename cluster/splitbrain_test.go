@@ -0,0 +1,46 @@
+package cluster
+
+import (
+	"strconv"
+	"testing"
+)
+
+func splitNodes(names ...string) []*Node {
+	var nodes []*Node
+	for _, name := range names {
+		started, _ := strconv.Atoi(name[1:])
+		nodes = append(nodes, &Node{
+			Name: name,
+			Meta: map[string]string{MetaIncarnation: strconv.Itoa(started)},
+		})
+	}
+	return nodes
+}
+
+func TestSplitBrainDecisions(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      SplitBrainConfig
+		reachable   []string
+		unreachable []string
+		want        splitBrainDecision
+	}{
+		{"majority keeps larger side", SplitBrainConfig{Strategy: KeepMajority}, []string{"n1", "n2", "n3"}, []string{"n4", "n5"}, downUnreachable},
+		{"majority downs smaller side", SplitBrainConfig{Strategy: KeepMajority}, []string{"n4", "n5"}, []string{"n1", "n2", "n3"}, downReachable},
+		{"majority tie keeps lowest name", SplitBrainConfig{Strategy: KeepMajority}, []string{"n2", "n3"}, []string{"n1", "n4"}, downReachable},
+		{"oldest keeps its side", SplitBrainConfig{Strategy: KeepOldest}, []string{"n1"}, []string{"n2", "n3"}, downUnreachable},
+		{"oldest alone is downed", SplitBrainConfig{Strategy: KeepOldest, DownIfAlone: true}, []string{"n2", "n3"}, []string{"n1"}, downUnreachable},
+		{"referee side survives", SplitBrainConfig{Strategy: KeepReferee, Referee: "n3"}, []string{"n3", "n4"}, []string{"n1", "n2", "n5"}, downUnreachable},
+		{"side without referee is downed", SplitBrainConfig{Strategy: KeepReferee, Referee: "n3"}, []string{"n1", "n2", "n5"}, []string{"n3", "n4"}, downReachable},
+		{"quorum reached", SplitBrainConfig{Strategy: StaticQuorum, QuorumSize: 2}, []string{"n1", "n2"}, []string{"n3"}, downUnreachable},
+		{"quorum missed", SplitBrainConfig{Strategy: StaticQuorum, QuorumSize: 3}, []string{"n1", "n2"}, []string{"n3"}, downReachable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.config.decide(splitNodes(tt.reachable...), splitNodes(tt.unreachable...)); got != tt.want {
+				t.Errorf("Expected decision %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package cluster
+
+import (
+	"encoding/json"
+)
+
+type StateDelegate interface {
+	LocalState() []byte
+	MergeRemoteState(data []byte)
+}
+
+func (c *Cluster) RegisterState(name string, state StateDelegate) {
+	c.delegates.mtx.Lock()
+	defer c.delegates.mtx.Unlock()
+	c.delegates.states[name] = state
+}
+
+func (d *clusterDelegate) LocalState(join bool) []byte {
+	d.mtx.RLock()
+	defer d.mtx.RUnlock()
+
+	if len(d.states) == 0 {
+		return []byte{}
+	}
+
+	states := make(map[string][]byte, len(d.states))
+	for name, state := range d.states {
+		states[name] = state.LocalState()
+	}
+
+	data, err := json.Marshal(states)
+	if err != nil {
+		return []byte{}
+	}
+	return data
+}
+
+func (d *clusterDelegate) MergeRemoteState(buf []byte, join bool) {
+	if len(buf) == 0 {
+		return
+	}
+
+	var states map[string][]byte
+	if err := json.Unmarshal(buf, &states); err != nil {
+		return
+	}
+
+	d.mtx.RLock()
+	delegates := make(map[string]StateDelegate, len(d.states))
+	for name, state := range d.states {
+		delegates[name] = state
+	}
+	d.mtx.RUnlock()
+
+	for name, data := range states {
+		if state, exists := delegates[name]; exists {
+			state.MergeRemoteState(data)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kleeedolinux/gorilix/messaging"
+)
+
+const (
+	KindPublish     = "topic-publish"
+	KindTopicState  = "topic-state"
+	HeaderTopic     = "gorilix-topic"
+	topicsStateName = "topics"
+)
+
+type topicState struct {
+	Node    string   `json:"node"`
+	Version int64    `json:"version"`
+	Topics  []string `json:"topics"`
+}
+
+type topicRouter struct {
+	cluster  *Cluster
+	bus      *messaging.MessageBus
+	remote   map[string]map[string]struct{}
+	versions map[string]int64
+	mu       sync.RWMutex
+}
+
+func newTopicRouter(c *Cluster, bus *messaging.MessageBus) *topicRouter {
+	t := &topicRouter{
+		cluster:  c,
+		bus:      bus,
+		remote:   make(map[string]map[string]struct{}),
+		versions: make(map[string]int64),
+	}
+
+	c.HandleKind(KindPublish, t.handlePublish)
+	c.HandleKind(KindTopicState, t.handleState)
+	c.RegisterState(topicsStateName, t)
+	c.OnNodeEvent(t.handleNodeEvent)
+	bus.SetRemotePublisher(t)
+
+	return t
+}
+
+func (c *Cluster) TopicNodes(topic string) []string {
+	if c.topics == nil {
+		return nil
+	}
+	return c.topics.nodes(topic)
+}
+
+func (t *topicRouter) PublishRemote(ctx context.Context, topic string, msg messaging.Message) error {
+	if !t.cluster.running.Load() {
+		return nil
+	}
+
+	msg.Headers = withHeader(msg.Headers, HeaderKind, KindPublish)
+	msg.Headers[HeaderTopic] = topic
+
+	var lastErr error
+	for _, node := range t.nodes(topic) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := t.cluster.deliver(ctx, node, &msg); err != nil {
+			lastErr = err
+		}
+	}
+	return lastErr
+}
+
+func (t *topicRouter) SubscriptionsChanged() {
+	if !t.cluster.running.Load() {
+		return
+	}
+
+	state := t.localState()
+	for _, node := range t.cluster.Members() {
+		if node.Name != state.Node && node.Status == NodeAlive {
+			t.sendState(node.Name, state)
+		}
+	}
+}
+
+func (t *topicRouter) LocalState() []byte {
+	data, _ := json.Marshal(t.localState())
+	return data
+}
+
+func (t *topicRouter) MergeRemoteState(data []byte) {
+	var state topicState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return
+	}
+	t.replace(state)
+}
+
+func (t *topicRouter) handlePublish(ctx context.Context, msg *messaging.Message) error {
+	topic := msg.Headers[HeaderTopic]
+
+	out := *msg
+	out.Headers = make(map[string]string, len(msg.Headers))
+	for k, v := range msg.Headers {
+		if k != HeaderKind && k != HeaderTopic {
+			out.Headers[k] = v
+		}
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), defaultDeliveryTimeout)
+		defer cancel()
+
+		if err := t.bus.PublishLocal(ctx, topic, out); err != nil {
+			t.cluster.reportFailure(msg, err)
+		}
+	}()
+	return nil
+}
+
+func (t *topicRouter) handleState(ctx context.Context, msg *messaging.Message) error {
+	var state topicState
+	if err := DecodePayload(msg, &state); err != nil {
+		return nil
+	}
+	t.replace(state)
+	return nil
+}
+
+func (t *topicRouter) sendState(node string, state topicState) {
+	_ = t.cluster.Send(node, &messaging.Message{
+		Type:    messaging.System,
+		Payload: state,
+		Headers: map[string]string{HeaderKind: KindTopicState},
+	})
+}
+
+func (t *topicRouter) handleNodeEvent(event NodeEvent) {
+	if event.Type == NodeUp {
+		t.sendState(event.Node, t.localState())
+		return
+	}
+
+	if event.Type == NodeDown {
+		t.mu.Lock()
+		delete(t.remote, event.Node)
+		delete(t.versions, event.Node)
+		t.mu.Unlock()
+	}
+}
+
+func (t *topicRouter) localState() topicState {
+	topics := t.bus.Topics()
+	sort.Strings(topics)
+	return topicState{Node: t.cluster.config.NodeName, Version: time.Now().UnixNano(), Topics: topics}
+}
+
+func (t *topicRouter) replace(state topicState) {
+	if state.Node == "" || state.Node == t.cluster.config.NodeName {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if state.Version < t.versions[state.Node] {
+		return
+	}
+	t.versions[state.Node] = state.Version
+
+	if len(state.Topics) == 0 {
+		delete(t.remote, state.Node)
+		return
+	}
+
+	topics := make(map[string]struct{}, len(state.Topics))
+	for _, topic := range state.Topics {
+		topics[topic] = struct{}{}
+	}
+	t.remote[state.Node] = topics
+}
+
+func (t *topicRouter) nodes(topic string) []string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	var nodes []string
+	for node, topics := range t.remote {
+		if _, subscribed := topics[topic]; subscribed {
+			nodes = append(nodes, node)
+		}
+	}
+	sort.Strings(nodes)
+	return nodes
+}
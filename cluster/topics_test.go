@@ -0,0 +1,93 @@
+package cluster
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kleeedolinux/gorilix/messaging"
+)
+
+type busSystem struct {
+	SystemReference
+	bus *messaging.MessageBus
+}
+
+func (s *busSystem) GetMessageBus() *messaging.MessageBus {
+	return s.bus
+}
+
+type topicSubscriber struct {
+	id       string
+	block    bool
+	received chan messaging.Message
+}
+
+func (s *topicSubscriber) Send(ctx context.Context, message interface{}) error {
+	if s.block {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	s.received <- message.(messaging.Message)
+	return nil
+}
+
+func (s *topicSubscriber) ID() string {
+	return s.id
+}
+
+func (s *topicSubscriber) IsRunning() bool {
+	return true
+}
+
+func TestHandlePublishDoesNotWaitForSubscribers(t *testing.T) {
+	bus := messaging.NewMessageBus()
+	c := NewCluster(&ClusterConfig{NodeName: "n1"}, &busSystem{bus: bus})
+
+	fast := &topicSubscriber{id: "fast", received: make(chan messaging.Message, 1)}
+	bus.Subscribe("orders", &topicSubscriber{id: "slow", block: true})
+	bus.Subscribe("orders", fast)
+
+	msg := &messaging.Message{
+		ID:      "m1",
+		Payload: "order",
+		Headers: map[string]string{HeaderKind: KindPublish, HeaderTopic: "orders", "trace": "t1"},
+	}
+
+	start := time.Now()
+	if err := c.topics.handlePublish(context.Background(), msg); err != nil {
+		t.Fatalf("handlePublish failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("Expected handlePublish to return without waiting for subscribers, took %v", elapsed)
+	}
+
+	select {
+	case got := <-fast.received:
+		if !reflect.DeepEqual(got.Headers, map[string]string{"trace": "t1"}) {
+			t.Errorf("Expected routing headers to be stripped, got %v", got.Headers)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the fast subscriber to receive the message")
+	}
+}
+
+func TestTopicStateTracksRemoteSubscribers(t *testing.T) {
+	c := NewCluster(&ClusterConfig{NodeName: "n1"}, &busSystem{bus: messaging.NewMessageBus()})
+
+	c.topics.replace(topicState{Node: "n3", Version: 1, Topics: []string{"orders"}})
+	c.topics.replace(topicState{Node: "n2", Version: 2, Topics: []string{"orders", "audit"}})
+	c.topics.replace(topicState{Node: "n2", Version: 1, Topics: nil})
+
+	if nodes := c.TopicNodes("orders"); !reflect.DeepEqual(nodes, []string{"n2", "n3"}) {
+		t.Errorf("Expected n2 and n3 for orders, got %v", nodes)
+	}
+
+	c.topics.replace(topicState{Node: "n3", Version: 2})
+	c.topics.handleNodeEvent(NodeEvent{Type: NodeDown, Node: "n2"})
+
+	if nodes := c.TopicNodes("orders"); len(nodes) != 0 {
+		t.Errorf("Expected no remote subscribers, got %v", nodes)
+	}
+}
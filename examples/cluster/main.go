@@ -10,6 +10,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/kleeedolinux/gorilix/actor"
 	"github.com/kleeedolinux/gorilix/cluster/bridge"
 	"github.com/kleeedolinux/gorilix/messaging"
 	"github.com/kleeedolinux/gorilix/system"
@@ -52,13 +53,13 @@ func main() {
 
 	
 	ctx := context.Background()
-	actor, err := actorSystem.SpawnActor("receiver", messageHandler, 100)
+	receiver, err := actorSystem.SpawnActor("receiver", messageHandler, 100)
 	if err != nil {
 		log.Fatalf("Failed to spawn actor: %v", err)
 	}
 
 	
-	err = actorSystem.RegisterName("message-receiver", actor)
+	err = actorSystem.RegisterName("message-receiver", receiver)
 	if err != nil {
 		log.Fatalf("Failed to register actor: %v", err)
 	}
@@ -99,9 +100,11 @@ func main() {
 							Headers:   map[string]string{"content-type": "text/plain"},
 						}
 
-						
-						
-						err := actorSystem.SendNamedMessage(ctx, "message-receiver", msg)
+						address := actor.Address{Node: member.GetName(), Name: "message-receiver"}
+						remoteRef, err := actorSystem.GetActor(address.String())
+						if err == nil {
+							err = remoteRef.Send(ctx, msg)
+						}
 						if err != nil {
 							fmt.Printf("Error sending message: %v\n", err)
 						}
@@ -130,6 +133,11 @@ func messageHandler(ctx context.Context, message interface{}) error {
 		return fmt.Errorf("invalid message type: %T", message)
 	}
 
-	fmt.Printf("Received message from %s: %v\n", msg.Sender, msg.Payload)
+	payload := msg.Payload
+	if data, ok := payload.([]byte); ok {
+		payload = string(data)
+	}
+
+	fmt.Printf("Received message from %s: %v\n", msg.Sender, payload)
 	return nil
 }
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/kleeedolinux/gorilix/actor"
+	"github.com/kleeedolinux/gorilix/supervisor"
 )
 
 type InitFunc func(ctx context.Context, args interface{}) (interface{}, error)
@@ -44,15 +45,20 @@ type Options struct {
 	BufferSize    int
 	InitArgs      interface{}
 	Name          string
+	CrashOnError  bool
 }
 
 type GenServer struct {
 	*actor.DefaultActor
-	options     Options
-	state       interface{}
-	initCalled  bool
-	terminateCh chan struct{}
-	mu          sync.RWMutex
+	options        Options
+	state          interface{}
+	initCalled     bool
+	terminateCh    chan struct{}
+	terminateOnce  sync.Once
+	failureHandler func(error)
+	crashReason    error
+	crashed        bool
+	mu             sync.RWMutex
 }
 
 func New(id string, options Options) *GenServer {
@@ -78,6 +84,7 @@ func Start(id string, options Options) (*GenServer, actor.ActorRef, error) {
 		var err error
 		gs.state, err = options.InitFunc(ctx, options.InitArgs)
 		if err != nil {
+			_ = gs.DefaultActor.Stop()
 			return nil, nil, fmt.Errorf("failed to initialize GenServer: %w", err)
 		}
 		gs.initCalled = true
@@ -86,12 +93,59 @@ func Start(id string, options Options) (*GenServer, actor.ActorRef, error) {
 	return gs, ref, nil
 }
 
+func ChildSpec(id string, options Options, restartType supervisor.RestartType) supervisor.ChildSpec {
+	return supervisor.ChildSpec{
+		ID: id,
+		CreateFunc: func() (actor.Actor, error) {
+			gs, _, err := Start(id, options)
+			if err != nil {
+				return nil, err
+			}
+			return gs, nil
+		},
+		RestartType: restartType,
+	}
+}
+
+func (g *GenServer) SetFailureHandler(handler func(error)) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.failureHandler = handler
+}
+
 func (g *GenServer) processMessage(ctx context.Context, msg interface{}) error {
+	panicked, err := g.handleMessage(ctx, msg)
+	if err == nil || (!panicked && !g.options.CrashOnError) {
+		return err
+	}
+
+	g.mu.Lock()
+	handler := g.failureHandler
+	report := handler != nil && !g.crashed
+	if report {
+		g.crashed = true
+		g.crashReason = err
+	}
+	g.mu.Unlock()
+
+	if report {
+		go handler(err)
+	}
+	return err
+}
+
+func (g *GenServer) handleMessage(ctx context.Context, msg interface{}) (panicked bool, err error) {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("GenServer %s panicked: %v", g.ID(), r)
+			panicked = true
+		}
+	}()
+
 	var newState interface{}
-	var err error
 
 	switch m := msg.(type) {
 	case *CallMessage:
@@ -115,14 +169,14 @@ func (g *GenServer) processMessage(ctx context.Context, msg interface{}) error {
 	}
 
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	if newState != nil {
 		g.state = newState
 	}
 
-	return nil
+	return false, nil
 }
 
 func (g *GenServer) handleCall(ctx context.Context, msg *CallMessage) (interface{}, interface{}, error) {
@@ -147,16 +201,19 @@ func (g *GenServer) handleInfo(ctx context.Context, msg interface{}) (interface{
 }
 
 func (g *GenServer) Stop() error {
-	g.mu.Lock()
-	terminateFunc := g.options.TerminateFunc
-	state := g.state
-	g.mu.Unlock()
-
-	if terminateFunc != nil {
-		terminateFunc(context.Background(), nil, state)
-	}
+	g.terminateOnce.Do(func() {
+		g.mu.Lock()
+		terminateFunc := g.options.TerminateFunc
+		state := g.state
+		reason := g.crashReason
+		g.mu.Unlock()
+
+		if terminateFunc != nil {
+			terminateFunc(context.Background(), reason, state)
+		}
 
-	close(g.terminateCh)
+		close(g.terminateCh)
+	})
 	return g.DefaultActor.Stop()
 }
 
@@ -2,7 +2,11 @@ module github.com/kleeedolinux/gorilix
 
 go 1.24.2
 
-require github.com/hashicorp/memberlist v0.5.3
+require (
+	github.com/hashicorp/go-msgpack/v2 v2.1.3
+	github.com/hashicorp/memberlist v0.5.3
+	google.golang.org/protobuf v1.36.9
+)
 
 require (
 	github.com/armon/go-metrics v0.4.1 // indirect
@@ -11,7 +15,6 @@ require (
 	github.com/hashicorp/go-immutable-radix v1.3.1 // indirect
 	github.com/hashicorp/go-metrics v0.5.4 // indirect
 	github.com/hashicorp/go-msgpack v0.5.5 // indirect
-	github.com/hashicorp/go-msgpack/v2 v2.1.3 // indirect
 	github.com/hashicorp/go-multierror v1.1.1 // indirect
 	github.com/hashicorp/go-sockaddr v1.0.7 // indirect
 	github.com/hashicorp/golang-lru v1.0.2 // indirect
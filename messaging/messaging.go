@@ -30,9 +30,15 @@ type Message struct {
 	Headers   map[string]string
 }
 
+type RemotePublisher interface {
+	PublishRemote(ctx context.Context, topic string, msg Message) error
+	SubscriptionsChanged()
+}
+
 type MessageBus struct {
 	subscribers      map[string][]actor.ActorRef
 	topicLock        sync.RWMutex
+	remote           RemotePublisher
 	deliveryTimeout  time.Duration
 	retries          int
 	ackedDelivery    bool
@@ -58,25 +64,44 @@ func (m *MessageBus) SetDeliveryOptions(timeout time.Duration, retries int, acke
 	m.ackedDelivery = ackedDelivery
 }
 
-func (m *MessageBus) Subscribe(topic string, subscriber actor.ActorRef) {
+func (m *MessageBus) DeliveryOptions() (time.Duration, int, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.deliveryTimeout, m.retries, m.ackedDelivery
+}
+
+func (m *MessageBus) SetRemotePublisher(remote RemotePublisher) {
 	m.topicLock.Lock()
 	defer m.topicLock.Unlock()
+	m.remote = remote
+}
+
+func (m *MessageBus) Subscribe(topic string, subscriber actor.ActorRef) {
+	m.topicLock.Lock()
 
 	for _, sub := range m.subscribers[topic] {
 		if sub.ID() == subscriber.ID() {
+			m.topicLock.Unlock()
 			return
 		}
 	}
 
 	m.subscribers[topic] = append(m.subscribers[topic], subscriber)
+	remote := m.remote
+	first := len(m.subscribers[topic]) == 1
+	m.topicLock.Unlock()
+
+	if remote != nil && first {
+		remote.SubscriptionsChanged()
+	}
 }
 
 func (m *MessageBus) Unsubscribe(topic string, subscriberID string) {
 	m.topicLock.Lock()
-	defer m.topicLock.Unlock()
 
 	subs, exists := m.subscribers[topic]
 	if !exists {
+		m.topicLock.Unlock()
 		return
 	}
 
@@ -88,9 +113,47 @@ func (m *MessageBus) Unsubscribe(topic string, subscriberID string) {
 	}
 
 	m.subscribers[topic] = newSubs
+	if len(newSubs) == 0 {
+		delete(m.subscribers, topic)
+	}
+	remote := m.remote
+	m.topicLock.Unlock()
+
+	if remote != nil && len(newSubs) == 0 {
+		remote.SubscriptionsChanged()
+	}
+}
+
+func (m *MessageBus) Topics() []string {
+	m.topicLock.RLock()
+	defer m.topicLock.RUnlock()
+
+	topics := make([]string, 0, len(m.subscribers))
+	for topic, subs := range m.subscribers {
+		if len(subs) > 0 {
+			topics = append(topics, topic)
+		}
+	}
+	return topics
 }
 
 func (m *MessageBus) Publish(ctx context.Context, topic string, msg Message) error {
+	m.topicLock.RLock()
+	remote := m.remote
+	m.topicLock.RUnlock()
+
+	err := m.PublishLocal(ctx, topic, msg)
+
+	if remote != nil {
+		if remoteErr := remote.PublishRemote(ctx, topic, msg); remoteErr != nil && err == nil {
+			err = remoteErr
+		}
+	}
+
+	return err
+}
+
+func (m *MessageBus) PublishLocal(ctx context.Context, topic string, msg Message) error {
 	m.topicLock.RLock()
 	subscribers := m.subscribers[topic]
 	m.topicLock.RUnlock()
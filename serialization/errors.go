@@ -0,0 +1,15 @@
+package serialization
+
+import "errors"
+
+var (
+	ErrTypeNotRegistered = errors.New("payload type not registered")
+
+	ErrTypeAlreadyRegistered = errors.New("payload type already registered")
+
+	ErrSerializerNotFound = errors.New("serializer not found")
+
+	ErrInvalidSchemaVersion = errors.New("invalid schema version")
+
+	ErrNoConverter = errors.New("no schema converter registered")
+)
@@ -0,0 +1,268 @@
+package serialization
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+type Manifest struct {
+	Type       string `json:"type"`
+	Serializer string `json:"serializer"`
+	Version    int    `json:"version"`
+}
+
+type Encoded struct {
+	Manifest
+	Data []byte
+}
+
+type Converter func(data []byte) ([]byte, error)
+
+type binding struct {
+	name       string
+	typ        reflect.Type
+	serializer string
+	version    int
+}
+
+type Registry struct {
+	serializers map[string]Serializer
+	byType      map[reflect.Type]binding
+	byName      map[string]binding
+	upcasters   map[string]map[int]Converter
+	downcasters map[string]map[int]Converter
+	mu          sync.RWMutex
+}
+
+var DefaultRegistry = NewRegistry()
+
+func NewRegistry() *Registry {
+	r := &Registry{
+		serializers: make(map[string]Serializer),
+		byType:      make(map[reflect.Type]binding),
+		byName:      make(map[string]binding),
+		upcasters:   make(map[string]map[int]Converter),
+		downcasters: make(map[string]map[int]Converter),
+	}
+
+	r.RegisterSerializer(JSONSerializer{})
+	r.RegisterSerializer(GobSerializer{})
+	r.RegisterSerializer(MsgPackSerializer{})
+
+	return r
+}
+
+func (r *Registry) RegisterSerializer(serializer Serializer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.serializers[serializer.Name()] = serializer
+}
+
+func (r *Registry) Register(name string, sample interface{}) error {
+	return r.RegisterWith(name, sample, JSON)
+}
+
+func (r *Registry) RegisterWith(name string, sample interface{}, serializer string) error {
+	return r.RegisterVersion(name, sample, serializer, 1)
+}
+
+func (r *Registry) RegisterVersion(name string, sample interface{}, serializer string, version int) error {
+	typ := reflect.TypeOf(sample)
+	if typ == nil {
+		return fmt.Errorf("cannot register nil sample for %s", name)
+	}
+	if version < 1 {
+		return fmt.Errorf("%w: %d for %s", ErrInvalidSchemaVersion, version, name)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.serializers[serializer]; !exists {
+		return fmt.Errorf("%w: %s", ErrSerializerNotFound, serializer)
+	}
+
+	if existing, exists := r.byName[name]; exists && existing.typ != typ {
+		return fmt.Errorf("%w: %s", ErrTypeAlreadyRegistered, name)
+	}
+	if existing, exists := r.byType[typ]; exists && existing.name != name {
+		return fmt.Errorf("%w: %s is registered as %s", ErrTypeAlreadyRegistered, typ, existing.name)
+	}
+
+	b := binding{name: name, typ: typ, serializer: serializer, version: version}
+	r.byType[typ] = b
+	r.byName[name] = b
+	return nil
+}
+
+func (r *Registry) RegisterUpcaster(name string, fromVersion int, upcaster Converter) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.upcasters[name] == nil {
+		r.upcasters[name] = make(map[int]Converter)
+	}
+	r.upcasters[name][fromVersion] = upcaster
+}
+
+func (r *Registry) RegisterDowncaster(name string, fromVersion int, downcaster Converter) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.downcasters[name] == nil {
+		r.downcasters[name] = make(map[int]Converter)
+	}
+	r.downcasters[name][fromVersion] = downcaster
+}
+
+func (r *Registry) Manifest(v interface{}) (Manifest, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	b, exists := r.byType[reflect.TypeOf(v)]
+	if !exists {
+		return Manifest{}, false
+	}
+	return Manifest{Type: b.name, Serializer: b.serializer, Version: b.version}, true
+}
+
+func (r *Registry) Versions() map[string]int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	versions := make(map[string]int, len(r.byName))
+	for name, b := range r.byName {
+		versions[name] = b.version
+	}
+	return versions
+}
+
+func (r *Registry) Encode(v interface{}) (Encoded, error) {
+	r.mu.RLock()
+	b, exists := r.byType[reflect.TypeOf(v)]
+	s := r.serializers[b.serializer]
+	r.mu.RUnlock()
+
+	if !exists {
+		return Encoded{}, fmt.Errorf("%w: %T", ErrTypeNotRegistered, v)
+	}
+
+	data, err := s.Marshal(v)
+	if err != nil {
+		return Encoded{}, fmt.Errorf("failed to encode %s with %s: %w", b.name, b.serializer, err)
+	}
+
+	return Encoded{
+		Manifest: Manifest{Type: b.name, Serializer: b.serializer, Version: b.version},
+		Data:     data,
+	}, nil
+}
+
+func (r *Registry) EncodeVersion(v interface{}, version int) (Encoded, error) {
+	encoded, err := r.Encode(v)
+	if err != nil || encoded.Version == version {
+		return encoded, err
+	}
+
+	data, err := r.convert(encoded.Type, encoded.Data, encoded.Version, version)
+	if err != nil {
+		return Encoded{}, err
+	}
+
+	encoded.Version = version
+	encoded.Data = data
+	return encoded, nil
+}
+
+func (r *Registry) Decode(encoded Encoded) (interface{}, error) {
+	r.mu.RLock()
+	b, exists := r.byName[encoded.Type]
+	s, found := r.serializers[encoded.Serializer]
+	r.mu.RUnlock()
+
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrTypeNotRegistered, encoded.Type)
+	}
+	if !found {
+		return nil, fmt.Errorf("%w: %s", ErrSerializerNotFound, encoded.Serializer)
+	}
+
+	version := encoded.Version
+	if version == 0 {
+		version = 1
+	}
+
+	data, err := r.convert(encoded.Type, encoded.Data, version, b.version)
+	if err != nil {
+		return nil, err
+	}
+
+	var target reflect.Value
+	if b.typ.Kind() == reflect.Ptr {
+		target = reflect.New(b.typ.Elem())
+	} else {
+		target = reflect.New(b.typ)
+	}
+
+	if err := s.Unmarshal(data, target.Interface()); err != nil {
+		return nil, fmt.Errorf("failed to decode %s with %s: %w", encoded.Type, encoded.Serializer, err)
+	}
+
+	if b.typ.Kind() == reflect.Ptr {
+		return target.Interface(), nil
+	}
+	return target.Elem().Interface(), nil
+}
+
+func (r *Registry) convert(name string, data []byte, from, to int) ([]byte, error) {
+	r.mu.RLock()
+	upcasters := r.upcasters[name]
+	downcasters := r.downcasters[name]
+	r.mu.RUnlock()
+
+	for from != to {
+		converters, next := upcasters, from+1
+		if from > to {
+			converters, next = downcasters, from-1
+		}
+
+		converter, exists := converters[from]
+		if !exists {
+			return nil, fmt.Errorf("%w: %s from version %d to %d", ErrNoConverter, name, from, next)
+		}
+
+		var err error
+		data, err = converter(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert %s from version %d to %d: %w", name, from, next, err)
+		}
+		from = next
+	}
+
+	return data, nil
+}
+
+func Register(name string, sample interface{}) error {
+	return DefaultRegistry.Register(name, sample)
+}
+
+func RegisterWith(name string, sample interface{}, serializer string) error {
+	return DefaultRegistry.RegisterWith(name, sample, serializer)
+}
+
+func RegisterVersion(name string, sample interface{}, serializer string, version int) error {
+	return DefaultRegistry.RegisterVersion(name, sample, serializer, version)
+}
+
+func RegisterUpcaster(name string, fromVersion int, upcaster Converter) {
+	DefaultRegistry.RegisterUpcaster(name, fromVersion, upcaster)
+}
+
+func RegisterDowncaster(name string, fromVersion int, downcaster Converter) {
+	DefaultRegistry.RegisterDowncaster(name, fromVersion, downcaster)
+}
+
+func RegisterSerializer(serializer Serializer) {
+	DefaultRegistry.RegisterSerializer(serializer)
+}
@@ -0,0 +1,125 @@
+package serialization
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+type order struct {
+	ID       string
+	Quantity int
+	Tags     []string
+}
+
+func TestRegistryPreservesConcreteType(t *testing.T) {
+	for _, serializer := range []string{JSON, Gob, MsgPack} {
+		r := NewRegistry()
+		if err := r.RegisterWith("test.Order", order{}, serializer); err != nil {
+			t.Fatalf("RegisterWith(%s) failed: %v", serializer, err)
+		}
+		if err := r.RegisterWith("test.OrderRef", &order{}, serializer); err != nil {
+			t.Fatalf("RegisterWith(%s) failed: %v", serializer, err)
+		}
+
+		sent := order{ID: "o-1", Quantity: 3, Tags: []string{"rush"}}
+		encoded, err := r.Encode(sent)
+		if err != nil {
+			t.Fatalf("Encode with %s failed: %v", serializer, err)
+		}
+		if encoded.Type != "test.Order" || encoded.Serializer != serializer || encoded.Version != 1 {
+			t.Errorf("Expected manifest test.Order/%s/1, got %+v", serializer, encoded.Manifest)
+		}
+
+		decoded, err := r.Decode(encoded)
+		if err != nil {
+			t.Fatalf("Decode with %s failed: %v", serializer, err)
+		}
+		received, ok := decoded.(order)
+		if !ok {
+			t.Fatalf("Expected order with %s, got %T", serializer, decoded)
+		}
+		if received.ID != sent.ID || received.Quantity != sent.Quantity || len(received.Tags) != 1 {
+			t.Errorf("Expected %+v with %s, got %+v", sent, serializer, received)
+		}
+
+		encoded, err = r.Encode(&sent)
+		if err != nil {
+			t.Fatalf("Encode pointer with %s failed: %v", serializer, err)
+		}
+		decoded, err = r.Decode(encoded)
+		if err != nil {
+			t.Fatalf("Decode pointer with %s failed: %v", serializer, err)
+		}
+		if ref, ok := decoded.(*order); !ok || ref.ID != sent.ID {
+			t.Errorf("Expected *order with %s, got %#v", serializer, decoded)
+		}
+	}
+}
+
+func TestRegistryRejectsUnknownTypes(t *testing.T) {
+	r := NewRegistry()
+
+	if _, err := r.Encode(order{}); !errors.Is(err, ErrTypeNotRegistered) {
+		t.Errorf("Expected ErrTypeNotRegistered, got %v", err)
+	}
+	if err := r.RegisterWith("test.Order", order{}, "yaml"); !errors.Is(err, ErrSerializerNotFound) {
+		t.Errorf("Expected ErrSerializerNotFound, got %v", err)
+	}
+	if err := r.Register("test.Order", order{}); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	if err := r.Register("test.Other", order{}); !errors.Is(err, ErrTypeAlreadyRegistered) {
+		t.Errorf("Expected ErrTypeAlreadyRegistered, got %v", err)
+	}
+}
+
+type orderV2 struct {
+	ID    string
+	Items int
+}
+
+func TestRegistryConvertsBetweenSchemaVersions(t *testing.T) {
+	r := NewRegistry()
+	if err := r.RegisterVersion("test.Order", orderV2{}, JSON, 2); err != nil {
+		t.Fatalf("RegisterVersion failed: %v", err)
+	}
+
+	r.RegisterUpcaster("test.Order", 1, func(data []byte) ([]byte, error) {
+		var v1 order
+		if err := json.Unmarshal(data, &v1); err != nil {
+			return nil, err
+		}
+		return json.Marshal(orderV2{ID: v1.ID, Items: v1.Quantity})
+	})
+	r.RegisterDowncaster("test.Order", 2, func(data []byte) ([]byte, error) {
+		var v2 orderV2
+		if err := json.Unmarshal(data, &v2); err != nil {
+			return nil, err
+		}
+		return json.Marshal(order{ID: v2.ID, Quantity: v2.Items})
+	})
+
+	old, _ := json.Marshal(order{ID: "o-1", Quantity: 4})
+	decoded, err := r.Decode(Encoded{Manifest: Manifest{Type: "test.Order", Serializer: JSON, Version: 1}, Data: old})
+	if err != nil {
+		t.Fatalf("Decode of version 1 failed: %v", err)
+	}
+	if upcast, ok := decoded.(orderV2); !ok || upcast.Items != 4 {
+		t.Errorf("Expected upcast orderV2 with 4 items, got %#v", decoded)
+	}
+
+	encoded, err := r.EncodeVersion(orderV2{ID: "o-2", Items: 5}, 1)
+	if err != nil {
+		t.Fatalf("EncodeVersion failed: %v", err)
+	}
+	var downcast order
+	if err := json.Unmarshal(encoded.Data, &downcast); err != nil || encoded.Version != 1 || downcast.Quantity != 5 {
+		t.Errorf("Expected version 1 order with quantity 5, got %+v (%s)", encoded.Manifest, encoded.Data)
+	}
+
+	_, err = r.Decode(Encoded{Manifest: Manifest{Type: "test.Order", Serializer: JSON, Version: 3}, Data: []byte("{}")})
+	if !errors.Is(err, ErrNoConverter) {
+		t.Errorf("Expected ErrNoConverter for version 3, got %v", err)
+	}
+}
@@ -0,0 +1,73 @@
+package serialization
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+
+	"github.com/hashicorp/go-msgpack/v2/codec"
+)
+
+const (
+	JSON    = "json"
+	Gob     = "gob"
+	MsgPack = "msgpack"
+)
+
+type Serializer interface {
+	Name() string
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
+}
+
+type JSONSerializer struct{}
+
+func (JSONSerializer) Name() string {
+	return JSON
+}
+
+func (JSONSerializer) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (JSONSerializer) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+type GobSerializer struct{}
+
+func (GobSerializer) Name() string {
+	return Gob
+}
+
+func (GobSerializer) Marshal(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (GobSerializer) Unmarshal(data []byte, v interface{}) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+type MsgPackSerializer struct{}
+
+var msgpackHandle = &codec.MsgpackHandle{}
+
+func (MsgPackSerializer) Name() string {
+	return MsgPack
+}
+
+func (MsgPackSerializer) Marshal(v interface{}) ([]byte, error) {
+	var data []byte
+	if err := codec.NewEncoderBytes(&data, msgpackHandle).Encode(v); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (MsgPackSerializer) Unmarshal(data []byte, v interface{}) error {
+	return codec.NewDecoderBytes(data, msgpackHandle).Decode(v)
+}
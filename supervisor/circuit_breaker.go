@@ -0,0 +1,310 @@
+package supervisor
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type CircuitBreakerState int
+
+const (
+	Closed CircuitBreakerState = iota
+	Open
+	HalfOpen
+)
+
+func (s CircuitBreakerState) String() string {
+	switch s {
+	case Closed:
+		return "closed"
+	case Open:
+		return "open"
+	case HalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+type CircuitBreaker interface {
+	GetState() CircuitBreakerState
+	RecordFailure() bool
+	RecordSuccess()
+	Reset()
+	ShouldAllow() bool
+	TripThreshold() int
+	FailureWindow() time.Duration
+	ResetTimeout() time.Duration
+}
+
+type ObservableCircuitBreaker interface {
+	CircuitBreaker
+	Execute(ctx context.Context, fn func(context.Context) error) error
+	OnStateChange(callback func(from, to CircuitBreakerState))
+	Metrics() CircuitBreakerMetrics
+}
+
+type CircuitBreakerMetrics struct {
+	State           CircuitBreakerState
+	Successes       int64
+	Failures        int64
+	Rejections      int64
+	StateChanges    int64
+	RecentFailures  int
+	LastFailure     time.Time
+	LastStateChange time.Time
+}
+
+type breakerState struct {
+	state     CircuitBreakerState
+	changedAt time.Time
+}
+
+type DefaultCircuitBreaker struct {
+	current            atomic.Pointer[breakerState]
+	probes             atomic.Int32
+	maxProbes          atomic.Int32
+	tripThreshold      int
+	failureWindow      time.Duration
+	resetTimeout       time.Duration
+	successThreshold   int
+	failures           int
+	consecutiveSuccess int
+	lastFailure        time.Time
+	mu                 sync.Mutex
+	callbacks          []func(from, to CircuitBreakerState)
+	callbacksMu        sync.RWMutex
+	successCount       atomic.Int64
+	failureCount       atomic.Int64
+	rejectionCount     atomic.Int64
+	stateChangeCount   atomic.Int64
+}
+
+func NewCircuitBreaker(tripThreshold int, failureWindow, resetTimeout time.Duration, successThreshold int) CircuitBreaker {
+	return newCircuitBreaker(tripThreshold, failureWindow, resetTimeout, successThreshold)
+}
+
+func newCircuitBreaker(tripThreshold int, failureWindow, resetTimeout time.Duration, successThreshold int) *DefaultCircuitBreaker {
+	cb := &DefaultCircuitBreaker{
+		tripThreshold:    tripThreshold,
+		failureWindow:    failureWindow,
+		resetTimeout:     resetTimeout,
+		successThreshold: successThreshold,
+	}
+	cb.current.Store(&breakerState{state: Closed, changedAt: time.Now()})
+	cb.maxProbes.Store(1)
+	return cb
+}
+
+func (cb *DefaultCircuitBreaker) SetHalfOpenProbes(n int) {
+	if n < 1 {
+		n = 1
+	}
+	cb.maxProbes.Store(int32(n))
+}
+
+func (cb *DefaultCircuitBreaker) GetState() CircuitBreakerState {
+	current := cb.current.Load()
+
+	if current.state == Open && time.Since(current.changedAt) > cb.resetTimeout {
+		cb.transition(Open, HalfOpen)
+		return cb.current.Load().state
+	}
+
+	return current.state
+}
+
+func (cb *DefaultCircuitBreaker) RecordFailure() bool {
+	cb.failureCount.Add(1)
+	cb.releaseProbe()
+	state := cb.GetState()
+
+	cb.mu.Lock()
+	now := time.Now()
+
+	if !cb.lastFailure.IsZero() && now.Sub(cb.lastFailure) > cb.failureWindow {
+		cb.failures = 0
+	}
+
+	cb.failures++
+	cb.lastFailure = now
+	cb.consecutiveSuccess = 0
+	failures := cb.failures
+	cb.mu.Unlock()
+
+	switch state {
+	case Closed:
+		if failures >= cb.tripThreshold {
+			return cb.transition(Closed, Open)
+		}
+	case HalfOpen:
+		cb.transition(HalfOpen, Open)
+	}
+
+	return false
+}
+
+func (cb *DefaultCircuitBreaker) RecordSuccess() {
+	cb.successCount.Add(1)
+	cb.releaseProbe()
+
+	if cb.GetState() != HalfOpen {
+		return
+	}
+
+	cb.mu.Lock()
+	cb.consecutiveSuccess++
+	shouldClose := cb.consecutiveSuccess >= cb.successThreshold
+	cb.mu.Unlock()
+
+	if shouldClose && cb.transition(HalfOpen, Closed) {
+		cb.mu.Lock()
+		cb.failures = 0
+		cb.consecutiveSuccess = 0
+		cb.mu.Unlock()
+	}
+}
+
+func (cb *DefaultCircuitBreaker) Reset() {
+	cb.mu.Lock()
+	cb.failures = 0
+	cb.consecutiveSuccess = 0
+	cb.mu.Unlock()
+
+	from := cb.current.Swap(&breakerState{state: Closed, changedAt: time.Now()}).state
+	if from != Closed {
+		cb.stateChangeCount.Add(1)
+		cb.notify(from, Closed)
+	}
+}
+
+func (cb *DefaultCircuitBreaker) ShouldAllow() bool {
+	state := cb.GetState()
+
+	switch state {
+	case Closed:
+		return true
+	case Open:
+		cb.rejectionCount.Add(1)
+		return false
+	case HalfOpen:
+		if !cb.acquireProbe() {
+			cb.rejectionCount.Add(1)
+			return false
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+func (cb *DefaultCircuitBreaker) Execute(ctx context.Context, fn func(context.Context) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if !cb.ShouldAllow() {
+		return ErrCircuitBreakerOpen
+	}
+
+	if err := fn(ctx); err != nil {
+		cb.RecordFailure()
+		return err
+	}
+
+	cb.RecordSuccess()
+	return nil
+}
+
+func (cb *DefaultCircuitBreaker) acquireProbe() bool {
+	for {
+		probes := cb.probes.Load()
+		if probes >= cb.maxProbes.Load() {
+			return false
+		}
+		if cb.probes.CompareAndSwap(probes, probes+1) {
+			return true
+		}
+	}
+}
+
+func (cb *DefaultCircuitBreaker) releaseProbe() {
+	for {
+		probes := cb.probes.Load()
+		if probes <= 0 {
+			return
+		}
+		if cb.probes.CompareAndSwap(probes, probes-1) {
+			return
+		}
+	}
+}
+
+func (cb *DefaultCircuitBreaker) OnStateChange(callback func(from, to CircuitBreakerState)) {
+	cb.callbacksMu.Lock()
+	defer cb.callbacksMu.Unlock()
+	cb.callbacks = append(cb.callbacks, callback)
+}
+
+func (cb *DefaultCircuitBreaker) Metrics() CircuitBreakerMetrics {
+	state := cb.GetState()
+	changedAt := cb.current.Load().changedAt
+
+	cb.mu.Lock()
+	recentFailures := cb.failures
+	lastFailure := cb.lastFailure
+	cb.mu.Unlock()
+
+	return CircuitBreakerMetrics{
+		State:           state,
+		Successes:       cb.successCount.Load(),
+		Failures:        cb.failureCount.Load(),
+		Rejections:      cb.rejectionCount.Load(),
+		StateChanges:    cb.stateChangeCount.Load(),
+		RecentFailures:  recentFailures,
+		LastFailure:     lastFailure,
+		LastStateChange: changedAt,
+	}
+}
+
+func (cb *DefaultCircuitBreaker) TripThreshold() int {
+	return cb.tripThreshold
+}
+
+func (cb *DefaultCircuitBreaker) FailureWindow() time.Duration {
+	return cb.failureWindow
+}
+
+func (cb *DefaultCircuitBreaker) ResetTimeout() time.Duration {
+	return cb.resetTimeout
+}
+
+func (cb *DefaultCircuitBreaker) transition(from, to CircuitBreakerState) bool {
+	current := cb.current.Load()
+	if current.state != from {
+		return false
+	}
+	if !cb.current.CompareAndSwap(current, &breakerState{state: to, changedAt: time.Now()}) {
+		return false
+	}
+
+	if to == HalfOpen {
+		cb.probes.Store(0)
+	}
+	cb.stateChangeCount.Add(1)
+	cb.notify(from, to)
+	return true
+}
+
+func (cb *DefaultCircuitBreaker) notify(from, to CircuitBreakerState) {
+	cb.callbacksMu.RLock()
+	callbacks := make([]func(from, to CircuitBreakerState), len(cb.callbacks))
+	copy(callbacks, cb.callbacks)
+	cb.callbacksMu.RUnlock()
+
+	for _, callback := range callbacks {
+		callback(from, to)
+	}
+}
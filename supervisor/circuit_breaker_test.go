@@ -1,6 +1,9 @@
 package supervisor
 
 import (
+	"context"
+	"errors"
+	"sync"
 	"testing"
 	"time"
 )
@@ -99,3 +102,131 @@ func TestCircuitBreakerFailureWindow(t *testing.T) {
 		t.Errorf("Expected state Open after 3 quick failures, got %v", state)
 	}
 }
+
+func TestCircuitBreakerExecute(t *testing.T) {
+
+	cb := NewCircuitBreaker(2, time.Second, 50*time.Millisecond, 1).(ObservableCircuitBreaker)
+
+	var transitions []CircuitBreakerState
+	cb.OnStateChange(func(from, to CircuitBreakerState) {
+		transitions = append(transitions, to)
+	})
+
+	failing := func(ctx context.Context) error { return errors.New("downstream unavailable") }
+	succeeding := func(ctx context.Context) error { return nil }
+
+	ctx := context.Background()
+	_ = cb.Execute(ctx, failing)
+	_ = cb.Execute(ctx, failing)
+
+	if err := cb.Execute(ctx, succeeding); !errors.Is(err, ErrCircuitBreakerOpen) {
+		t.Errorf("Expected ErrCircuitBreakerOpen while open, got %v", err)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+
+	if err := cb.Execute(ctx, succeeding); err != nil {
+		t.Errorf("Expected call to pass in half-open state, got %v", err)
+	}
+
+	if state := cb.GetState(); state != Closed {
+		t.Errorf("Expected state Closed after successful trial call, got %v", state)
+	}
+
+	expected := []CircuitBreakerState{Open, HalfOpen, Closed}
+	if len(transitions) != len(expected) {
+		t.Fatalf("Expected transitions %v, got %v", expected, transitions)
+	}
+	for i := range expected {
+		if transitions[i] != expected[i] {
+			t.Errorf("Expected transitions %v, got %v", expected, transitions)
+			break
+		}
+	}
+
+	metrics := cb.Metrics()
+	if metrics.Failures != 2 || metrics.Successes != 1 || metrics.Rejections != 1 {
+		t.Errorf("Unexpected metrics: %+v", metrics)
+	}
+}
+
+func TestCircuitBreakerConcurrentUse(t *testing.T) {
+
+	cb := NewCircuitBreaker(50, time.Second, time.Millisecond, 5).(ObservableCircuitBreaker)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				_ = cb.Execute(context.Background(), func(ctx context.Context) error {
+					if (i+j)%3 == 0 {
+						return errors.New("failure")
+					}
+					return nil
+				})
+				_ = cb.GetState()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	metrics := cb.Metrics()
+	if metrics.Successes+metrics.Failures+metrics.Rejections != 2000 {
+		t.Errorf("Expected 2000 recorded calls, got %+v", metrics)
+	}
+}
+
+func TestCircuitBreakerHalfOpenAllowsOneProbe(t *testing.T) {
+
+	cb := NewCircuitBreaker(1, time.Second, 20*time.Millisecond, 1).(ObservableCircuitBreaker)
+	cb.RecordFailure()
+	time.Sleep(30 * time.Millisecond)
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	go func() {
+		_ = cb.Execute(context.Background(), func(ctx context.Context) error {
+			close(started)
+			<-release
+			return nil
+		})
+	}()
+	<-started
+
+	if err := cb.Execute(context.Background(), func(ctx context.Context) error { return nil }); !errors.Is(err, ErrCircuitBreakerOpen) {
+		t.Errorf("Expected a second probe to be rejected while half-open, got %v", err)
+	}
+
+	close(release)
+	deadline := time.Now().Add(time.Second)
+	for cb.GetState() != Closed && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if state := cb.GetState(); state != Closed {
+		t.Errorf("Expected state Closed after the probe succeeded, got %v", state)
+	}
+}
+
+func TestCircuitBreakerShouldAllowLimitsProbes(t *testing.T) {
+
+	cb := NewCircuitBreaker(1, time.Second, 20*time.Millisecond, 2)
+	cb.RecordFailure()
+	time.Sleep(30 * time.Millisecond)
+
+	if !cb.ShouldAllow() {
+		t.Fatal("Expected the first probe to be allowed while half-open")
+	}
+	if cb.ShouldAllow() {
+		t.Error("Expected a second probe to be rejected until the first one is recorded")
+	}
+
+	cb.RecordSuccess()
+	if state := cb.GetState(); state != HalfOpen {
+		t.Fatalf("Expected state HalfOpen after one of two successes, got %v", state)
+	}
+	if !cb.ShouldAllow() {
+		t.Error("Expected another probe once the first one was recorded")
+	}
+}
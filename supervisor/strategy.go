@@ -28,14 +28,6 @@ const (
 	JitteredExponentialBackoff
 )
 
-type CircuitBreakerState int
-
-const (
-	Closed CircuitBreakerState = iota
-	Open
-	HalfOpen
-)
-
 type Strategy interface {
 	HandleFailure(ctx context.Context, childID string, err error) ([]string, error)
 
@@ -54,122 +46,6 @@ type Strategy interface {
 	CircuitBreaker() CircuitBreaker
 }
 
-type CircuitBreaker interface {
-	GetState() CircuitBreakerState
-	RecordFailure() bool
-	RecordSuccess()
-	Reset()
-	ShouldAllow() bool
-	TripThreshold() int
-	FailureWindow() time.Duration
-	ResetTimeout() time.Duration
-}
-
-type DefaultCircuitBreaker struct {
-	state              CircuitBreakerState
-	failures           int
-	tripThreshold      int
-	failureWindow      time.Duration
-	resetTimeout       time.Duration
-	lastFailure        time.Time
-	lastStateChange    time.Time
-	consecutiveSuccess int
-	successThreshold   int
-}
-
-func NewCircuitBreaker(tripThreshold int, failureWindow, resetTimeout time.Duration, successThreshold int) CircuitBreaker {
-	return &DefaultCircuitBreaker{
-		state:              Closed,
-		failures:           0,
-		tripThreshold:      tripThreshold,
-		failureWindow:      failureWindow,
-		resetTimeout:       resetTimeout,
-		lastFailure:        time.Time{},
-		lastStateChange:    time.Now(),
-		consecutiveSuccess: 0,
-		successThreshold:   successThreshold,
-	}
-}
-
-func (cb *DefaultCircuitBreaker) GetState() CircuitBreakerState {
-	now := time.Now()
-
-	if cb.state == Open && now.Sub(cb.lastStateChange) > cb.resetTimeout {
-		cb.state = HalfOpen
-		cb.lastStateChange = now
-	}
-
-	return cb.state
-}
-
-func (cb *DefaultCircuitBreaker) RecordFailure() bool {
-	now := time.Now()
-
-	if !cb.lastFailure.IsZero() && now.Sub(cb.lastFailure) > cb.failureWindow {
-		cb.failures = 0
-	}
-
-	cb.failures++
-	cb.lastFailure = now
-	cb.consecutiveSuccess = 0
-
-	if cb.state == Closed && cb.failures >= cb.tripThreshold {
-		cb.state = Open
-		cb.lastStateChange = now
-		return true
-	}
-
-	if cb.state == HalfOpen {
-		cb.state = Open
-		cb.lastStateChange = now
-	}
-
-	return false
-}
-
-func (cb *DefaultCircuitBreaker) RecordSuccess() {
-	if cb.state == HalfOpen {
-		cb.consecutiveSuccess++
-		if cb.consecutiveSuccess >= cb.successThreshold {
-			cb.Reset()
-		}
-	}
-}
-
-func (cb *DefaultCircuitBreaker) Reset() {
-	cb.state = Closed
-	cb.failures = 0
-	cb.lastStateChange = time.Now()
-	cb.consecutiveSuccess = 0
-}
-
-func (cb *DefaultCircuitBreaker) ShouldAllow() bool {
-	state := cb.GetState()
-
-	switch state {
-	case Closed:
-		return true
-	case Open:
-		return false
-	case HalfOpen:
-		return true
-	default:
-		return false
-	}
-}
-
-func (cb *DefaultCircuitBreaker) TripThreshold() int {
-	return cb.tripThreshold
-}
-
-func (cb *DefaultCircuitBreaker) FailureWindow() time.Duration {
-	return cb.failureWindow
-}
-
-func (cb *DefaultCircuitBreaker) ResetTimeout() time.Duration {
-	return cb.resetTimeout
-}
-
 type DefaultStrategy struct {
 	strategyType           RestartStrategy
 	maxRestarts            int
@@ -197,6 +73,8 @@ type CircuitBreakerOptions struct {
 	FailureWindow    time.Duration
 	ResetTimeout     time.Duration
 	SuccessThreshold int
+	HalfOpenProbes   int
+	OnStateChange    func(from, to CircuitBreakerState)
 }
 
 func DefaultStrategyOptions() StrategyOptions {
@@ -222,18 +100,24 @@ func NewStrategy(strategyType RestartStrategy, maxRestarts, timeInterval int) St
 }
 
 func NewStrategyWithOptions(strategyType RestartStrategy, maxRestarts, timeInterval int, options StrategyOptions) Strategy {
-	var cb CircuitBreaker
+	var cb *DefaultCircuitBreaker
 	if options.CircuitBreakerOptions != nil && options.CircuitBreakerOptions.Enabled {
 		cbOpts := options.CircuitBreakerOptions
-		cb = NewCircuitBreaker(
+		cb = newCircuitBreaker(
 			cbOpts.TripThreshold,
 			cbOpts.FailureWindow,
 			cbOpts.ResetTimeout,
 			cbOpts.SuccessThreshold,
 		)
+		if cbOpts.HalfOpenProbes > 0 {
+			cb.SetHalfOpenProbes(cbOpts.HalfOpenProbes)
+		}
+		if cbOpts.OnStateChange != nil {
+			cb.OnStateChange(cbOpts.OnStateChange)
+		}
 	} else {
 
-		cb = NewCircuitBreaker(9999, 24*time.Hour, 1*time.Millisecond, 1)
+		cb = newCircuitBreaker(9999, 24*time.Hour, 1*time.Millisecond, 1)
 	}
 
 	return &DefaultStrategy{
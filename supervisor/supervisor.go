@@ -13,6 +13,12 @@ type ChildSpec struct {
 	CreateFunc  func() (actor.Actor, error)
 	RestartType RestartType
 	Args        map[string]interface{}
+	OnStart     func(ref actor.ActorRef) error
+	OnStop      func(ref actor.ActorRef)
+}
+
+type FailureReporter interface {
+	SetFailureHandler(handler func(error))
 }
 
 type RestartType int
@@ -55,7 +61,7 @@ type DefaultSupervisor struct {
 	*actor.DefaultActor
 	strategy       Strategy
 	children       map[string]actor.Actor
-	childRefs      map[string]actor.ActorRef
+	childRefs      map[string]*actor.StableRef
 	childSpecs     map[string]ChildSpec
 	childOrder     []string
 	restartHistory []time.Time
@@ -73,7 +79,7 @@ func NewSupervisor(id string, strategy Strategy) *DefaultSupervisor {
 	s := &DefaultSupervisor{
 		strategy:       strategy,
 		children:       make(map[string]actor.Actor),
-		childRefs:      make(map[string]actor.ActorRef),
+		childRefs:      make(map[string]*actor.StableRef),
 		childSpecs:     make(map[string]ChildSpec),
 		childOrder:     []string{},
 		restartHistory: []time.Time{},
@@ -118,32 +124,64 @@ func (s *DefaultSupervisor) AddChild(spec ChildSpec) (actor.ActorRef, error) {
 		return nil, err
 	}
 
-	childRef := actor.NewActorRef(child)
+	childRef := actor.NewStableRef(child)
+	if spec.OnStart != nil {
+		if err := spec.OnStart(childRef); err != nil {
+			_ = child.Stop()
+			childRef.Terminate()
+			return nil, err
+		}
+	}
+
 	s.children[spec.ID] = child
 	s.childRefs[spec.ID] = childRef
 	s.childSpecs[spec.ID] = spec
 	s.childOrder = append(s.childOrder, spec.ID)
+	s.watchChild(spec.ID, child)
 
 	return childRef, nil
 }
 
+func (s *DefaultSupervisor) watchChild(id string, child actor.Actor) {
+	reporter, ok := child.(FailureReporter)
+	if !ok {
+		return
+	}
+
+	reporter.SetFailureHandler(func(err error) {
+		s.mu.RLock()
+		current := s.children[id]
+		s.mu.RUnlock()
+
+		if current != child {
+			return
+		}
+		_ = s.NotifyChildFailure(context.Background(), id, err)
+	})
+}
+
 func (s *DefaultSupervisor) RemoveChild(id string) error {
 	s.mu.Lock()
-	defer s.mu.Unlock()
-
 	if s.status != Running {
+		s.mu.Unlock()
 		return ErrSupervisorStopped
 	}
 
-	child, exists := s.children[id]
+	child, ref, exists := s.detachChild(id)
+	s.mu.Unlock()
+
 	if !exists {
 		return actor.ErrActorNotFound
 	}
+	return stopChild(child, ref)
+}
 
-	err := child.Stop()
-	if err != nil {
-		return err
+func (s *DefaultSupervisor) detachChild(id string) (actor.Actor, *actor.StableRef, bool) {
+	child, exists := s.children[id]
+	if !exists {
+		return nil, nil, false
 	}
+	ref := s.childRefs[id]
 
 	delete(s.children, id)
 	delete(s.childRefs, id)
@@ -156,7 +194,15 @@ func (s *DefaultSupervisor) RemoveChild(id string) error {
 		}
 	}
 
-	return nil
+	return child, ref, true
+}
+
+func stopChild(child actor.Actor, ref *actor.StableRef) error {
+	err := child.Stop()
+	if ref != nil {
+		ref.Terminate()
+	}
+	return err
 }
 
 func (s *DefaultSupervisor) GetChild(id string) (actor.ActorRef, error) {
@@ -259,19 +305,21 @@ func (s *DefaultSupervisor) handleChildFailure(ctx context.Context, childID stri
 		}
 	}
 
-	if !s.strategy.CircuitBreaker().ShouldAllow() {
-
-		return ErrCircuitBreakerOpen
-	}
-
 	if !s.shouldRestart(childID, err) {
-
-		delete(s.children, childID)
-		delete(s.childRefs, childID)
-
+		spec := s.childSpecs[childID]
+		if child, ref, exists := s.detachChild(childID); exists {
+			_ = stopChild(child, ref)
+			if spec.OnStop != nil {
+				go spec.OnStop(ref)
+			}
+		}
 		return nil
 	}
 
+	if !s.strategy.CircuitBreaker().ShouldAllow() {
+		return ErrCircuitBreakerOpen
+	}
+
 	s.status = Restarting
 	childrenToRestart, err := s.strategy.HandleFailure(ctx, childID, err)
 	if err != nil {
@@ -317,7 +365,18 @@ func (s *DefaultSupervisor) handleChildFailure(ctx context.Context, childID stri
 		}
 
 		s.children[id] = newChild
-		s.childRefs[id] = actor.NewActorRef(newChild)
+		ref, exists := s.childRefs[id]
+		if exists {
+			ref.Rebind(newChild)
+		} else {
+			ref = actor.NewStableRef(newChild)
+			s.childRefs[id] = ref
+		}
+		s.watchChild(id, newChild)
+
+		if spec.OnStart != nil {
+			_ = spec.OnStart(ref)
+		}
 	}
 
 	s.strategy.CircuitBreaker().RecordSuccess()
@@ -333,12 +392,18 @@ func (s *DefaultSupervisor) Stop() error {
 	}
 
 	s.status = Stopping
-	childIDs := make([]string, len(s.childOrder))
-	copy(childIDs, s.childOrder)
+	var children []actor.Actor
+	var refs []*actor.StableRef
+	for i := len(s.childOrder) - 1; i >= 0; i-- {
+		if child, ref, exists := s.detachChild(s.childOrder[i]); exists {
+			children = append(children, child)
+			refs = append(refs, ref)
+		}
+	}
 	s.mu.Unlock()
 
-	for _, id := range childIDs {
-		_ = s.RemoveChild(id)
+	for i, child := range children {
+		_ = stopChild(child, refs[i])
 	}
 
 	s.mu.Lock()
@@ -348,8 +413,116 @@ func (s *DefaultSupervisor) Stop() error {
 	return s.DefaultActor.Stop()
 }
 
+func (s *DefaultSupervisor) Drain(ctx context.Context) error {
+	s.mu.RLock()
+	children := make([]actor.Actor, 0, len(s.childOrder))
+	for i := len(s.childOrder) - 1; i >= 0; i-- {
+		if child, exists := s.children[s.childOrder[i]]; exists {
+			children = append(children, child)
+		}
+	}
+	s.mu.RUnlock()
+
+	for _, child := range children {
+		if drainer, ok := child.(actor.Drainer); ok {
+			if err := drainer.Drain(ctx); err != nil {
+				return err
+			}
+		}
+	}
+
+	return s.DefaultActor.Drain(ctx)
+}
+
 func (s *DefaultSupervisor) GetLastFailure() error {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 	return s.lastFailure
 }
+
+type SupervisorRef struct {
+	ref *actor.StableRef
+}
+
+func NewSupervisorRef(ref *actor.StableRef) *SupervisorRef {
+	return &SupervisorRef{ref: ref}
+}
+
+func (r *SupervisorRef) current() (Supervisor, error) {
+	sup, ok := r.ref.Actor().(Supervisor)
+	if !ok {
+		return nil, ErrSupervisorStopped
+	}
+	return sup, nil
+}
+
+func (r *SupervisorRef) Receive(ctx context.Context, message interface{}) error {
+	return r.ref.Send(ctx, message)
+}
+
+func (r *SupervisorRef) Stop() error {
+	sup, err := r.current()
+	if err != nil {
+		return err
+	}
+	return sup.Stop()
+}
+
+func (r *SupervisorRef) ID() string {
+	return r.ref.ID()
+}
+
+func (r *SupervisorRef) IsRunning() bool {
+	return r.ref.IsRunning()
+}
+
+func (r *SupervisorRef) Incarnation() uint64 {
+	return r.ref.Incarnation()
+}
+
+func (r *SupervisorRef) AddChild(spec ChildSpec) (actor.ActorRef, error) {
+	sup, err := r.current()
+	if err != nil {
+		return nil, err
+	}
+	return sup.AddChild(spec)
+}
+
+func (r *SupervisorRef) RemoveChild(id string) error {
+	sup, err := r.current()
+	if err != nil {
+		return err
+	}
+	return sup.RemoveChild(id)
+}
+
+func (r *SupervisorRef) GetChild(id string) (actor.ActorRef, error) {
+	sup, err := r.current()
+	if err != nil {
+		return nil, err
+	}
+	return sup.GetChild(id)
+}
+
+func (r *SupervisorRef) Strategy() Strategy {
+	sup, err := r.current()
+	if err != nil {
+		return nil
+	}
+	return sup.Strategy()
+}
+
+func (r *SupervisorRef) Status() SupervisorStatus {
+	sup, err := r.current()
+	if err != nil {
+		return Stopped
+	}
+	return sup.Status()
+}
+
+func (r *SupervisorRef) Drain(ctx context.Context) error {
+	if drainer, ok := r.ref.Actor().(actor.Drainer); ok {
+		return drainer.Drain(ctx)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package supervisor
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/kleeedolinux/gorilix/actor"
+)
+
+type stopRecorder struct {
+	*actor.DefaultActor
+	stops *[]string
+	mu    *sync.Mutex
+}
+
+func (r *stopRecorder) Stop() error {
+	r.mu.Lock()
+	*r.stops = append(*r.stops, r.ID())
+	r.mu.Unlock()
+	return r.DefaultActor.Stop()
+}
+
+func TestSupervisorStopsChildrenInReverseOrder(t *testing.T) {
+	sup := NewSupervisor("sup", NewStrategy(OneForOne, 3, 5))
+
+	var stops []string
+	var mu sync.Mutex
+	var children []actor.Actor
+	for _, id := range []string{"first", "second", "third"} {
+		id := id
+		_, err := sup.AddChild(ChildSpec{
+			ID: id,
+			CreateFunc: func() (actor.Actor, error) {
+				child := &stopRecorder{
+					DefaultActor: actor.NewActor(id, func(context.Context, interface{}) error { return nil }, 10),
+					stops:        &stops,
+					mu:           &mu,
+				}
+				children = append(children, child)
+				return child, nil
+			},
+		})
+		if err != nil {
+			t.Fatalf("AddChild failed: %v", err)
+		}
+	}
+
+	if err := sup.Stop(); err != nil {
+		t.Fatalf("Stop failed: %v", err)
+	}
+
+	expected := []string{"third", "second", "first"}
+	if len(stops) != len(expected) {
+		t.Fatalf("Expected stops %v, got %v", expected, stops)
+	}
+	for i := range expected {
+		if stops[i] != expected[i] {
+			t.Errorf("Expected stop order %v, got %v", expected, stops)
+			break
+		}
+	}
+	for _, child := range children {
+		if child.IsRunning() {
+			t.Errorf("Expected child %s to be stopped", child.ID())
+		}
+	}
+}
@@ -0,0 +1,428 @@
+package system
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kleeedolinux/gorilix/actor"
+	"github.com/kleeedolinux/gorilix/supervisor"
+)
+
+type Application interface {
+	Start(ctx context.Context, system *ActorSystem) (supervisor.Supervisor, error)
+
+	PrepStop(ctx context.Context) error
+
+	Stop(ctx context.Context) error
+}
+
+type ApplicationSpec struct {
+	Name         string
+	Application  Application
+	Dependencies []string
+	RestartType  supervisor.RestartType
+}
+
+type runningApplication struct {
+	spec       *ApplicationSpec
+	supervisor supervisor.Supervisor
+	stopCh     chan struct{}
+}
+
+type startup struct {
+	done chan struct{}
+	err  error
+}
+
+type ApplicationController struct {
+	system   *ActorSystem
+	specs    map[string]*ApplicationSpec
+	running  map[string]*runningApplication
+	starting map[string]*startup
+	restarts map[string][]time.Time
+	order    []string
+	mu       sync.Mutex
+}
+
+func NewApplicationController(system *ActorSystem) *ApplicationController {
+	return &ApplicationController{
+		system:   system,
+		specs:    make(map[string]*ApplicationSpec),
+		running:  make(map[string]*runningApplication),
+		starting: make(map[string]*startup),
+		restarts: make(map[string][]time.Time),
+		order:    []string{},
+	}
+}
+
+func (c *ApplicationController) Register(spec ApplicationSpec) error {
+	if spec.Name == "" || spec.Application == nil {
+		return fmt.Errorf("application spec requires a name and an application")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, exists := c.specs[spec.Name]; exists {
+		return ErrApplicationAlreadyRegistered
+	}
+
+	c.specs[spec.Name] = &spec
+	return nil
+}
+
+func (c *ApplicationController) Start(ctx context.Context, name string) error {
+	c.mu.Lock()
+	order, err := c.resolve(name, make(map[string]bool), make(map[string]bool), nil)
+	c.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+	return c.startInOrder(ctx, order)
+}
+
+func (c *ApplicationController) StartAll(ctx context.Context) error {
+	c.mu.Lock()
+	names := make([]string, 0, len(c.specs))
+	for name := range c.specs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var order []string
+	visiting, visited := make(map[string]bool), make(map[string]bool)
+	for _, name := range names {
+		var err error
+		if order, err = c.resolve(name, visiting, visited, order); err != nil {
+			c.mu.Unlock()
+			return err
+		}
+	}
+	c.mu.Unlock()
+
+	return c.startInOrder(ctx, order)
+}
+
+func (c *ApplicationController) Stop(ctx context.Context, name string) error {
+	c.mu.Lock()
+	if _, running := c.running[name]; !running {
+		c.mu.Unlock()
+		return ErrApplicationNotRunning
+	}
+	apps := c.detach(c.affectedBy(name))
+	c.mu.Unlock()
+
+	return c.shutdown(ctx, apps)
+}
+
+func (c *ApplicationController) StopAll(ctx context.Context) error {
+	c.mu.Lock()
+	apps := c.detach(c.order)
+	c.mu.Unlock()
+
+	return c.shutdown(ctx, apps)
+}
+
+func (c *ApplicationController) Drain(ctx context.Context) error {
+	c.mu.Lock()
+	sups := make([]supervisor.Supervisor, 0, len(c.order))
+	for i := len(c.order) - 1; i >= 0; i-- {
+		sups = append(sups, c.running[c.order[i]].supervisor)
+	}
+	c.mu.Unlock()
+
+	for _, sup := range sups {
+		if drainer, ok := sup.(actor.Drainer); ok {
+			if err := drainer.Drain(ctx); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *ApplicationController) Running() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	names := make([]string, len(c.order))
+	copy(names, c.order)
+	return names
+}
+
+func (c *ApplicationController) GetSupervisor(name string) (supervisor.Supervisor, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	app, running := c.running[name]
+	if !running {
+		return nil, ErrApplicationNotRunning
+	}
+
+	return app.supervisor, nil
+}
+
+func (c *ApplicationController) startInOrder(ctx context.Context, order []string) error {
+	var started []string
+	for _, name := range order {
+		ok, err := c.startApplication(ctx, name)
+		if err != nil {
+			c.mu.Lock()
+			apps := c.detach(started)
+			c.mu.Unlock()
+
+			_ = c.shutdown(ctx, apps)
+			return err
+		}
+		if ok {
+			started = append(started, name)
+		}
+	}
+	return nil
+}
+
+func (c *ApplicationController) resolve(name string, visiting, visited map[string]bool, order []string) ([]string, error) {
+	if visited[name] {
+		return order, nil
+	}
+	if visiting[name] {
+		return nil, fmt.Errorf("%w: '%s'", ErrApplicationDependencyCycle, name)
+	}
+
+	spec, exists := c.specs[name]
+	if !exists {
+		return nil, fmt.Errorf("%w: '%s'", ErrApplicationNotFound, name)
+	}
+
+	visiting[name] = true
+	for _, dep := range spec.Dependencies {
+		var err error
+		order, err = c.resolve(dep, visiting, visited, order)
+		if err != nil {
+			return nil, err
+		}
+	}
+	visiting[name] = false
+	visited[name] = true
+
+	return append(order, name), nil
+}
+
+func (c *ApplicationController) affectedBy(name string) []string {
+	affected := map[string]bool{name: true}
+	result := []string{}
+
+	for _, appName := range c.order {
+		if appName == name {
+			result = append(result, appName)
+			continue
+		}
+		for _, dep := range c.specs[appName].Dependencies {
+			if affected[dep] {
+				affected[appName] = true
+				result = append(result, appName)
+				break
+			}
+		}
+	}
+
+	return result
+}
+
+func (c *ApplicationController) startApplication(ctx context.Context, name string) (bool, error) {
+	c.mu.Lock()
+	if _, running := c.running[name]; running {
+		c.mu.Unlock()
+		return false, nil
+	}
+	if pending, starting := c.starting[name]; starting {
+		c.mu.Unlock()
+
+		select {
+		case <-pending.done:
+			return false, pending.err
+		case <-ctx.Done():
+			return false, ctx.Err()
+		}
+	}
+	spec := c.specs[name]
+	pending := &startup{done: make(chan struct{})}
+	c.starting[name] = pending
+	c.mu.Unlock()
+
+	sup, err := spec.Application.Start(ctx, c.system)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.starting, name)
+	defer close(pending.done)
+
+	if err != nil {
+		pending.err = fmt.Errorf("failed to start application '%s': %w", name, err)
+		return false, pending.err
+	}
+
+	app := &runningApplication{
+		spec:       spec,
+		supervisor: sup,
+		stopCh:     make(chan struct{}),
+	}
+
+	c.running[name] = app
+	c.order = append(c.order, name)
+
+	go c.watch(app)
+	return true, nil
+}
+
+func (c *ApplicationController) detach(names []string) []*runningApplication {
+	names = append([]string(nil), names...)
+
+	var apps []*runningApplication
+	for i := len(names) - 1; i >= 0; i-- {
+		if app, running := c.running[names[i]]; running {
+			c.forget(app)
+			apps = append(apps, app)
+		}
+	}
+	return apps
+}
+
+func (c *ApplicationController) shutdown(ctx context.Context, apps []*runningApplication) error {
+	var firstErr error
+	for _, app := range apps {
+		if err := app.spec.Application.PrepStop(ctx); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		if app.supervisor != nil {
+			if err := app.supervisor.Stop(); err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+		if err := app.spec.Application.Stop(ctx); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func (c *ApplicationController) forget(app *runningApplication) {
+	close(app.stopCh)
+	delete(c.running, app.spec.Name)
+
+	for i, appName := range c.order {
+		if appName == app.spec.Name {
+			c.order = append(c.order[:i], c.order[i+1:]...)
+			break
+		}
+	}
+}
+
+func (c *ApplicationController) watch(app *runningApplication) {
+	done, ok := app.supervisor.(interface{ Done() <-chan struct{} })
+	if !ok {
+		return
+	}
+
+	select {
+	case <-app.stopCh:
+		return
+	case <-done.Done():
+	}
+
+	c.handleTermination(app)
+}
+
+func (c *ApplicationController) handleTermination(app *runningApplication) {
+	ctx := context.Background()
+
+	var reason error
+	if f, ok := app.supervisor.(interface{ GetLastFailure() error }); ok {
+		reason = f.GetLastFailure()
+	}
+
+	c.mu.Lock()
+	select {
+	case <-app.stopCh:
+		c.mu.Unlock()
+		return
+	default:
+	}
+
+	restart := shouldRestartApplication(app.spec.RestartType, reason)
+
+	var affected []string
+	var dependents []*runningApplication
+	var backoff time.Duration
+	if restart {
+		affected = c.affectedBy(app.spec.Name)
+		var others []string
+		for _, appName := range affected {
+			if appName != app.spec.Name {
+				others = append(others, appName)
+			}
+		}
+		dependents = c.detach(others)
+
+		var allowed bool
+		backoff, allowed = c.recordRestart(app)
+		if !allowed {
+			affected = nil
+		}
+	}
+	c.forget(app)
+	c.mu.Unlock()
+
+	_ = c.shutdown(ctx, dependents)
+	_ = app.spec.Application.Stop(ctx)
+
+	if len(affected) == 0 {
+		return
+	}
+	if backoff > 0 {
+		time.Sleep(backoff)
+	}
+	_ = c.startInOrder(ctx, affected)
+}
+
+// recordRestart reports the backoff before the next restart, or false once the restart limit is exceeded.
+func (c *ApplicationController) recordRestart(app *runningApplication) (time.Duration, bool) {
+	strategy := app.supervisor.Strategy()
+	if strategy == nil || strategy.MaxRestarts() <= 0 {
+		return 0, true
+	}
+
+	now := time.Now()
+	cutoff := now.Add(-time.Duration(strategy.TimeInterval()) * time.Second)
+
+	var history []time.Time
+	for _, t := range c.restarts[app.spec.Name] {
+		if t.After(cutoff) {
+			history = append(history, t)
+		}
+	}
+	history = append(history, now)
+	c.restarts[app.spec.Name] = history
+
+	if len(history) > strategy.MaxRestarts() {
+		delete(c.restarts, app.spec.Name)
+		return 0, false
+	}
+	return strategy.CalculateBackoff(len(history)), true
+}
+
+func shouldRestartApplication(restartType supervisor.RestartType, reason error) bool {
+	switch restartType {
+	case supervisor.Permanent:
+		return true
+	case supervisor.Transient:
+		return reason != nil
+	default:
+		return false
+	}
+}
@@ -0,0 +1,318 @@
+package system
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kleeedolinux/gorilix/supervisor"
+)
+
+type recordingApp struct {
+	name   string
+	events *[]string
+	mu     *sync.Mutex
+	sup    supervisor.Supervisor
+}
+
+func (a *recordingApp) record(event string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	*a.events = append(*a.events, event+":"+a.name)
+}
+
+func (a *recordingApp) Start(ctx context.Context, system *ActorSystem) (supervisor.Supervisor, error) {
+	a.record("start")
+	a.sup = supervisor.NewSupervisor(a.name, supervisor.NewStrategy(supervisor.OneForOne, 3, 5))
+	return a.sup, nil
+}
+
+func (a *recordingApp) PrepStop(ctx context.Context) error {
+	a.record("prep")
+	return nil
+}
+
+func (a *recordingApp) Stop(ctx context.Context) error {
+	a.record("stop")
+	return nil
+}
+
+func TestApplicationDependencyOrder(t *testing.T) {
+	sys := NewActorSystem("test")
+	defer sys.Stop()
+
+	var events []string
+	var mu sync.Mutex
+
+	apps := []ApplicationSpec{
+		{Name: "web", Dependencies: []string{"db", "cache"}},
+		{Name: "cache", Dependencies: []string{"db"}},
+		{Name: "db"},
+	}
+
+	for _, spec := range apps {
+		spec.Application = &recordingApp{name: spec.Name, events: &events, mu: &mu}
+		spec.RestartType = supervisor.Temporary
+		if err := sys.Applications().Register(spec); err != nil {
+			t.Fatalf("Register failed: %v", err)
+		}
+	}
+
+	if err := sys.Applications().Start(context.Background(), "web"); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	running := sys.Applications().Running()
+	expected := []string{"db", "cache", "web"}
+	if len(running) != len(expected) {
+		t.Fatalf("Expected %v running, got %v", expected, running)
+	}
+	for i := range expected {
+		if running[i] != expected[i] {
+			t.Errorf("Expected start order %v, got %v", expected, running)
+			break
+		}
+	}
+
+	events = nil
+	if err := sys.Applications().Stop(context.Background(), "db"); err != nil {
+		t.Fatalf("Stop failed: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	expectedStops := []string{"stop:web", "stop:cache", "stop:db"}
+	var stops []string
+	for _, e := range events {
+		if len(e) > 5 && e[:5] == "stop:" {
+			stops = append(stops, e)
+		}
+	}
+	if len(stops) != len(expectedStops) {
+		t.Fatalf("Expected stops %v, got %v", expectedStops, stops)
+	}
+	for i := range expectedStops {
+		if stops[i] != expectedStops[i] {
+			t.Errorf("Expected stop order %v, got %v", expectedStops, stops)
+			break
+		}
+	}
+}
+
+func TestApplicationDependencyCycle(t *testing.T) {
+	sys := NewActorSystem("test")
+	defer sys.Stop()
+
+	var events []string
+	var mu sync.Mutex
+
+	_ = sys.Applications().Register(ApplicationSpec{Name: "a", Dependencies: []string{"b"},
+		Application: &recordingApp{name: "a", events: &events, mu: &mu}})
+	_ = sys.Applications().Register(ApplicationSpec{Name: "b", Dependencies: []string{"a"},
+		Application: &recordingApp{name: "b", events: &events, mu: &mu}})
+
+	err := sys.Applications().Start(context.Background(), "a")
+	if !errors.Is(err, ErrApplicationDependencyCycle) {
+		t.Errorf("Expected dependency cycle error, got %v", err)
+	}
+}
+
+func TestPermanentApplicationRestart(t *testing.T) {
+	sys := NewActorSystem("test")
+	defer sys.Stop()
+
+	var events []string
+	var mu sync.Mutex
+	app := &recordingApp{name: "core", events: &events, mu: &mu}
+
+	_ = sys.Applications().Register(ApplicationSpec{Name: "core", Application: app, RestartType: supervisor.Permanent})
+	if err := sys.Applications().Start(context.Background(), "core"); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	first := app.sup
+	_ = first.Stop()
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		sup, err := sys.Applications().GetSupervisor("core")
+		if err == nil && sup != first {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Error("Expected permanent application to be restarted")
+}
+
+type reentrantApp struct {
+	recordingApp
+	running []string
+}
+
+func (a *reentrantApp) Start(ctx context.Context, system *ActorSystem) (supervisor.Supervisor, error) {
+	a.running = system.Applications().Running()
+	return a.recordingApp.Start(ctx, system)
+}
+
+func TestApplicationStartCallsBackIntoController(t *testing.T) {
+	sys := NewActorSystem("test")
+	defer sys.Stop()
+
+	var events []string
+	var mu sync.Mutex
+	app := &reentrantApp{recordingApp: recordingApp{name: "web", events: &events, mu: &mu}}
+
+	_ = sys.Applications().Register(ApplicationSpec{Name: "db", Application: &recordingApp{name: "db", events: &events, mu: &mu}})
+	_ = sys.Applications().Register(ApplicationSpec{Name: "web", Application: app, Dependencies: []string{"db"}})
+
+	done := make(chan error, 1)
+	go func() { done <- sys.Applications().StartAll(context.Background()) }()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("StartAll failed: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("StartAll deadlocked when Application.Start called back into the controller")
+	}
+
+	if len(app.running) != 1 || app.running[0] != "db" {
+		t.Errorf("Expected db running when web starts, got %v", app.running)
+	}
+}
+
+type crashingApp struct {
+	recordingApp
+}
+
+func (a *crashingApp) Start(ctx context.Context, system *ActorSystem) (supervisor.Supervisor, error) {
+	sup, err := a.recordingApp.Start(ctx, system)
+	go sup.Stop()
+	return sup, err
+}
+
+func TestPermanentApplicationRestartIntensity(t *testing.T) {
+	sys := NewActorSystem("test")
+	defer sys.Stop()
+
+	var events []string
+	var mu sync.Mutex
+	app := &crashingApp{recordingApp{name: "core", events: &events, mu: &mu}}
+
+	_ = sys.Applications().Register(ApplicationSpec{Name: "core", Application: app, RestartType: supervisor.Permanent})
+	if err := sys.Applications().Start(context.Background(), "core"); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	starts := func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		count := 0
+		for _, e := range events {
+			if e == "start:core" {
+				count++
+			}
+		}
+		return count
+	}
+	waitFor(t, func() bool { return starts() == 4 && len(sys.Applications().Running()) == 0 })
+
+	time.Sleep(100 * time.Millisecond)
+	if got := starts(); got != 4 {
+		t.Errorf("Expected the initial start and 3 restarts, got %d starts", got)
+	}
+}
+
+type blockingApp struct {
+	recordingApp
+	entered chan struct{}
+	release chan struct{}
+}
+
+func (a *blockingApp) Start(ctx context.Context, system *ActorSystem) (supervisor.Supervisor, error) {
+	close(a.entered)
+	<-a.release
+	return a.recordingApp.Start(ctx, system)
+}
+
+func TestConcurrentStartWaitsForDependency(t *testing.T) {
+	sys := NewActorSystem("test")
+	defer sys.Stop()
+
+	var events []string
+	var mu sync.Mutex
+	db := &blockingApp{recordingApp: recordingApp{name: "db", events: &events, mu: &mu},
+		entered: make(chan struct{}), release: make(chan struct{})}
+	web := &reentrantApp{recordingApp: recordingApp{name: "web", events: &events, mu: &mu}}
+
+	_ = sys.Applications().Register(ApplicationSpec{Name: "db", Application: db})
+	_ = sys.Applications().Register(ApplicationSpec{Name: "web", Application: web, Dependencies: []string{"db"}})
+
+	first := make(chan error, 1)
+	go func() { first <- sys.Applications().Start(context.Background(), "db") }()
+	<-db.entered
+
+	second := make(chan error, 1)
+	go func() { second <- sys.Applications().Start(context.Background(), "web") }()
+
+	select {
+	case err := <-second:
+		t.Fatalf("Expected web to wait for db, Start returned %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(db.release)
+
+	for _, done := range []chan error{first, second} {
+		if err := <-done; err != nil {
+			t.Fatalf("Start failed: %v", err)
+		}
+	}
+	if len(web.running) != 1 || web.running[0] != "db" {
+		t.Errorf("Expected db running when web starts, got %v", web.running)
+	}
+}
+
+type failingApp struct {
+	recordingApp
+}
+
+func (a *failingApp) Start(ctx context.Context, system *ActorSystem) (supervisor.Supervisor, error) {
+	a.record("start")
+	return nil, errors.New("boom")
+}
+
+func TestFailedStartStopsStartedDependencies(t *testing.T) {
+	sys := NewActorSystem("test")
+	defer sys.Stop()
+
+	var events []string
+	var mu sync.Mutex
+	_ = sys.Applications().Register(ApplicationSpec{Name: "db",
+		Application: &recordingApp{name: "db", events: &events, mu: &mu}})
+	_ = sys.Applications().Register(ApplicationSpec{Name: "cache", Dependencies: []string{"db"},
+		Application: &recordingApp{name: "cache", events: &events, mu: &mu}})
+	_ = sys.Applications().Register(ApplicationSpec{Name: "web", Dependencies: []string{"cache"},
+		Application: &failingApp{recordingApp{name: "web", events: &events, mu: &mu}}})
+
+	if err := sys.Applications().Start(context.Background(), "web"); err == nil {
+		t.Fatal("Expected Start to fail")
+	}
+	if running := sys.Applications().Running(); len(running) != 0 {
+		t.Errorf("Expected the started dependencies to be stopped, got %v running", running)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	var stops []string
+	for _, e := range events {
+		if len(e) > 5 && e[:5] == "stop:" {
+			stops = append(stops, e)
+		}
+	}
+	if len(stops) != 2 || stops[0] != "stop:cache" || stops[1] != "stop:db" {
+		t.Errorf("Expected cache then db to be stopped, got %v", stops)
+	}
+}
@@ -11,4 +11,22 @@ var (
 	ErrActorNotRegistered = errors.New("actor not registered in the system")
 	
 	ErrSystemStopped = errors.New("actor system is stopped")
+
+	ErrSystemShuttingDown = errors.New("actor system is shutting down")
+
+	ErrApplicationNotFound = errors.New("application not found")
+
+	ErrApplicationAlreadyRegistered = errors.New("application already registered")
+
+	ErrApplicationNotRunning = errors.New("application is not running")
+
+	ErrApplicationDependencyCycle = errors.New("application dependency cycle detected")
+
+	ErrFactoryNotFound = errors.New("actor factory not found")
+
+	ErrFactoryAlreadyRegistered = errors.New("actor factory already registered")
+
+	ErrSupervisorNotFound = errors.New("supervisor not found")
+
+	ErrInvalidSpawnArgs = errors.New("invalid spawn arguments")
 )
@@ -0,0 +1,216 @@
+package system
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/kleeedolinux/gorilix/actor"
+	"github.com/kleeedolinux/gorilix/genserver"
+	"github.com/kleeedolinux/gorilix/supervisor"
+)
+
+const defaultSpawnTimeout = 5 * time.Second
+
+type ActorFactory func(id string, args interface{}) (actor.Actor, error)
+
+type GenServerFactory func(args interface{}) (genserver.Options, error)
+
+type SpawnOptions struct {
+	ID          string                 `json:"id,omitempty"`
+	Supervisor  string                 `json:"supervisor,omitempty"`
+	RestartType supervisor.RestartType `json:"restart_type"`
+}
+
+type SpawnRequest struct {
+	Factory string       `json:"factory"`
+	Args    interface{}  `json:"args,omitempty"`
+	Options SpawnOptions `json:"options"`
+}
+
+type factory struct {
+	actor     ActorFactory
+	genServer GenServerFactory
+	args      reflect.Type
+}
+
+func (s *ActorSystem) RegisterActorFactory(name string, f ActorFactory) error {
+	return s.registerFactory(name, factory{actor: f})
+}
+
+func (s *ActorSystem) RegisterGenServerFactory(name string, f GenServerFactory) error {
+	return s.registerFactory(name, factory{genServer: f})
+}
+
+func (s *ActorSystem) RegisterFactoryArgs(name string, sample interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, exists := s.factories[name]
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrFactoryNotFound, name)
+	}
+
+	f.args = reflect.TypeOf(sample)
+	s.factories[name] = f
+	return nil
+}
+
+func (s *ActorSystem) UnregisterFactory(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.factories, name)
+}
+
+func (s *ActorSystem) registerFactory(name string, f factory) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.factories[name]; exists {
+		return ErrFactoryAlreadyRegistered
+	}
+
+	s.factories[name] = f
+	return nil
+}
+
+func (s *ActorSystem) SpawnFromFactory(factoryName string, args interface{}, options SpawnOptions) (actor.ActorRef, error) {
+	s.mu.RLock()
+	f, exists := s.factories[factoryName]
+	s.mu.RUnlock()
+
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrFactoryNotFound, factoryName)
+	}
+
+	args, err := f.decodeArgs(args)
+	if err != nil {
+		return nil, fmt.Errorf("%w for factory %s: %v", ErrInvalidSpawnArgs, factoryName, err)
+	}
+
+	id := options.ID
+	if id == "" {
+		id = fmt.Sprintf("%s-%d", factoryName, time.Now().UnixNano())
+	}
+
+	sup, err := s.lookupSupervisor(options.Supervisor)
+	if err != nil {
+		return nil, err
+	}
+
+	if f.genServer != nil {
+		gsOptions, err := f.genServer(args)
+		if err != nil {
+			return nil, fmt.Errorf("factory %s failed: %w", factoryName, err)
+		}
+		return s.SpawnSupervisedGenServer(sup, id, gsOptions, options.RestartType)
+	}
+
+	return s.spawnSupervisedActor(sup, supervisor.ChildSpec{
+		ID: id,
+		CreateFunc: func() (actor.Actor, error) {
+			return f.actor(id, args)
+		},
+		RestartType: options.RestartType,
+	})
+}
+
+func (f factory) decodeArgs(args interface{}) (interface{}, error) {
+	if f.args == nil || args == nil || reflect.TypeOf(args).AssignableTo(f.args) {
+		return args, nil
+	}
+
+	data, err := json.Marshal(args)
+	if err != nil {
+		return nil, err
+	}
+
+	target := reflect.New(f.args)
+	if err := json.Unmarshal(data, target.Interface()); err != nil {
+		return nil, err
+	}
+	return target.Elem().Interface(), nil
+}
+
+func (s *ActorSystem) SpawnRemote(node, factoryName string, args interface{}) (actor.ActorRef, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultSpawnTimeout)
+	defer cancel()
+
+	return s.SpawnRemoteWithOptions(ctx, node, factoryName, args, SpawnOptions{})
+}
+
+func (s *ActorSystem) SpawnRemoteWithOptions(ctx context.Context, node, factoryName string, args interface{},
+	options SpawnOptions) (actor.ActorRef, error) {
+
+	cluster, err := s.GetCluster()
+	if err != nil {
+		return nil, err
+	}
+
+	if node == cluster.Self().GetName() {
+		return s.SpawnFromFactory(factoryName, args, options)
+	}
+
+	return cluster.Spawn(ctx, node, SpawnRequest{
+		Factory: factoryName,
+		Args:    args,
+		Options: options,
+	})
+}
+
+func (s *ActorSystem) spawnSupervisedActor(sup supervisor.Supervisor, spec supervisor.ChildSpec) (actor.ActorRef, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.running {
+		return nil, ErrSystemStopped
+	}
+
+	if s.shuttingDown {
+		return nil, ErrSystemShuttingDown
+	}
+
+	if _, exists := s.registry[spec.ID]; exists {
+		return nil, actor.ErrInvalidActorID
+	}
+
+	spec.OnStop = s.forgetChild(spec.ID)
+	ref, err := sup.AddChild(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	s.registry[spec.ID] = ref
+	return ref, nil
+}
+
+func (s *ActorSystem) lookupSupervisor(name string) (supervisor.Supervisor, error) {
+	if name == "" {
+		return s.rootSupervisor, nil
+	}
+
+	s.mu.RLock()
+	ref, exists := s.registry[name]
+	s.mu.RUnlock()
+
+	if !exists {
+		ref, exists = s.namedRegistry.Lookup(name)
+	}
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrSupervisorNotFound, name)
+	}
+
+	if stableRef, ok := ref.(*actor.StableRef); ok {
+		if _, ok := stableRef.Actor().(supervisor.Supervisor); ok {
+			return supervisor.NewSupervisorRef(stableRef), nil
+		}
+	}
+
+	sup, ok := ref.(supervisor.Supervisor)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrSupervisorNotFound, name)
+	}
+	return sup, nil
+}
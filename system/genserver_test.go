@@ -0,0 +1,192 @@
+package system
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kleeedolinux/gorilix/genserver"
+	"github.com/kleeedolinux/gorilix/supervisor"
+)
+
+func counterOptions(inits *atomic.Int64, args *atomic.Value) genserver.Options {
+	return genserver.Options{
+		Name:     "counter",
+		InitArgs: 10,
+		InitFunc: func(ctx context.Context, initArgs interface{}) (interface{}, error) {
+			inits.Add(1)
+			args.Store(initArgs)
+			return initArgs, nil
+		},
+		CallHandler: func(ctx context.Context, msg interface{}, state interface{}) (interface{}, interface{}, error) {
+			if msg == "fail" {
+				return nil, state, errors.New("invalid request")
+			}
+			return state, state, nil
+		},
+		CastHandler: func(ctx context.Context, msg interface{}, state interface{}) (interface{}, error) {
+			if msg == "crash" {
+				panic("boom")
+			}
+			return state.(int) + 1, nil
+		},
+	}
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in time")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestGenServerRestartReinitializesAndKeepsName(t *testing.T) {
+	sys := NewActorSystem("test")
+	defer sys.Stop()
+
+	var inits atomic.Int64
+	var args atomic.Value
+	ref, err := sys.SpawnGenServer("counter", counterOptions(&inits, &args))
+	if err != nil {
+		t.Fatalf("SpawnGenServer failed: %v", err)
+	}
+
+	ctx := context.Background()
+	_ = genserver.MakeCast(ctx, ref, "increment")
+	if state, _ := genserver.MakeCallSync(ctx, ref, "get", time.Second); state != 11 {
+		t.Fatalf("Expected state 11 before the crash, got %v", state)
+	}
+
+	_ = genserver.MakeCast(ctx, ref, "crash")
+	waitFor(t, func() bool { return inits.Load() == 2 })
+
+	if args.Load() != 10 {
+		t.Errorf("Expected InitFunc to get the original InitArgs, got %v", args.Load())
+	}
+
+	named, found := sys.WhereIs("counter")
+	if !found {
+		t.Fatal("Expected the restarted GenServer to be registered under its name")
+	}
+	state, err := genserver.MakeCallSync(ctx, named, "get", time.Second)
+	if err != nil || state != 10 {
+		t.Errorf("Expected the restarted GenServer to answer with its initial state, got %v, %v", state, err)
+	}
+}
+
+func TestGenServerHandlerErrorDoesNotRestart(t *testing.T) {
+	sys := NewActorSystem("test")
+	defer sys.Stop()
+
+	var inits atomic.Int64
+	var args atomic.Value
+	ref, err := sys.SpawnGenServer("counter", counterOptions(&inits, &args))
+	if err != nil {
+		t.Fatalf("SpawnGenServer failed: %v", err)
+	}
+
+	ctx := context.Background()
+	_ = genserver.MakeCast(ctx, ref, "increment")
+	_, _ = genserver.MakeCallSync(ctx, ref, "fail", time.Second)
+
+	state, err := genserver.MakeCallSync(ctx, ref, "get", time.Second)
+	if err != nil || state != 11 {
+		t.Errorf("Expected the state to survive a handler error, got %v, %v", state, err)
+	}
+	if inits.Load() != 1 {
+		t.Errorf("Expected no restart after a handler error, got %d inits", inits.Load())
+	}
+}
+
+func TestGenServerUnderUserSupervisor(t *testing.T) {
+	sys := NewActorSystem("test")
+	defer sys.Stop()
+
+	sup, err := sys.SpawnSupervisor("workers", supervisor.OneForOne, 5, 60)
+	if err != nil {
+		t.Fatalf("SpawnSupervisor failed: %v", err)
+	}
+
+	var inits atomic.Int64
+	var args atomic.Value
+	ref, err := sys.SpawnSupervisedGenServer(sup, "counter", counterOptions(&inits, &args), supervisor.Transient)
+	if err != nil {
+		t.Fatalf("SpawnSupervisedGenServer failed: %v", err)
+	}
+	if child, err := sup.GetChild("counter"); err != nil || child.ID() != ref.ID() {
+		t.Fatalf("Expected the GenServer to be a child of the user supervisor, got %v, %v", child, err)
+	}
+
+	_ = genserver.MakeCast(context.Background(), ref, "crash")
+	waitFor(t, func() bool { return inits.Load() == 2 })
+}
+
+func TestGenServerRepeatedFailuresDoNotDeadlock(t *testing.T) {
+	sys := NewActorSystem("test")
+	defer sys.Stop()
+
+	var casts atomic.Int64
+	ref, err := sys.SpawnGenServer("flaky", genserver.Options{
+		CrashOnError: true,
+		CastHandler: func(ctx context.Context, msg interface{}, state interface{}) (interface{}, error) {
+			if casts.Add(1) == 2 {
+				time.Sleep(100 * time.Millisecond)
+			}
+			return state, errors.New("failed")
+		},
+	})
+	if err != nil {
+		t.Fatalf("SpawnGenServer failed: %v", err)
+	}
+
+	_ = genserver.MakeCast(context.Background(), ref, "first")
+	_ = genserver.MakeCast(context.Background(), ref, "second")
+	time.Sleep(20 * time.Millisecond)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := sys.SpawnActor("other", func(context.Context, interface{}) error { return nil }, 10)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("SpawnActor failed: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Root supervisor deadlocked after repeated GenServer failures")
+	}
+}
+
+func TestTemporaryGenServerIsStoppedAfterPanic(t *testing.T) {
+	sys := NewActorSystem("test")
+	defer sys.Stop()
+
+	var inits atomic.Int64
+	var args atomic.Value
+	ref, err := sys.SpawnSupervisedGenServer(nil, "counter", counterOptions(&inits, &args), supervisor.Temporary)
+	if err != nil {
+		t.Fatalf("SpawnSupervisedGenServer failed: %v", err)
+	}
+
+	_ = genserver.MakeCast(context.Background(), ref, "crash")
+	waitFor(t, func() bool {
+		_, named := sys.WhereIs("counter")
+		_, err := sys.GetActor("counter")
+		return !ref.IsRunning() && !named && err != nil
+	})
+
+	if inits.Load() != 1 {
+		t.Errorf("Expected a temporary child not to restart, got %d inits", inits.Load())
+	}
+	if _, err := sys.SpawnSupervisedGenServer(nil, "counter", counterOptions(&inits, &args), supervisor.Temporary); err != nil {
+		t.Errorf("Expected the id and name to be free again, got %v", err)
+	}
+}
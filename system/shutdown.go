@@ -0,0 +1,92 @@
+package system
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/kleeedolinux/gorilix/actor"
+)
+
+const (
+	defaultLeaveTimeout = 5 * time.Second
+	defaultStopTimeout  = 5 * time.Second
+)
+
+func (s *ActorSystem) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	if !s.running || s.shuttingDown {
+		s.mu.Unlock()
+		return nil
+	}
+	s.shuttingDown = true
+	s.mu.Unlock()
+
+	var drainErr error
+	if err := s.applications.Drain(ctx); err != nil {
+		drainErr = err
+	}
+	if drainErr == nil {
+		if drainer, ok := s.rootSupervisor.(actor.Drainer); ok {
+			drainErr = drainer.Drain(ctx)
+		}
+	}
+
+	stopCtx, cancel := context.WithTimeout(context.Background(), defaultStopTimeout)
+	defer cancel()
+	_ = s.applications.StopAll(stopCtx)
+
+	stopErr := s.rootSupervisor.Stop()
+
+	s.mu.RLock()
+	cluster := s.cluster
+	s.mu.RUnlock()
+
+	if cluster != nil {
+		_ = cluster.Leave(defaultLeaveTimeout)
+		_ = cluster.Stop()
+	}
+
+	s.mu.Lock()
+	s.running = false
+	s.shuttingDown = false
+	s.mu.Unlock()
+
+	if drainErr != nil {
+		return fmt.Errorf("shutdown did not drain all mailboxes: %w", drainErr)
+	}
+	return stopErr
+}
+
+func (s *ActorSystem) ShutdownOnSignal(timeout time.Duration, signals ...os.Signal) <-chan error {
+	if len(signals) == 0 {
+		signals = []os.Signal{syscall.SIGINT, syscall.SIGTERM}
+	}
+
+	result := make(chan error, 1)
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, signals...)
+
+	go func() {
+		defer close(result)
+		defer signal.Stop(sigCh)
+
+		<-sigCh
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+
+		result <- s.Shutdown(ctx)
+	}()
+
+	return result
+}
+
+func (s *ActorSystem) isShuttingDown() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.shuttingDown
+}
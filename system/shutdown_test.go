@@ -0,0 +1,66 @@
+package system
+
+import (
+	"context"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestShutdownDrainsMailboxes(t *testing.T) {
+	sys := NewActorSystem("test")
+
+	var processed atomic.Int64
+	ref, err := sys.SpawnActor("worker", func(ctx context.Context, msg interface{}) error {
+		time.Sleep(time.Millisecond)
+		processed.Add(1)
+		return nil
+	}, 1000)
+	if err != nil {
+		t.Fatalf("SpawnActor failed: %v", err)
+	}
+
+	for i := 0; i < 100; i++ {
+		_ = ref.Send(context.Background(), i)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := sys.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+	if processed.Load() != 100 {
+		t.Errorf("Expected the 100 queued messages to be processed, got %d", processed.Load())
+	}
+	if ref.IsRunning() {
+		t.Error("Expected worker to be stopped after shutdown")
+	}
+}
+
+func TestShutdownOnSignal(t *testing.T) {
+	sys := NewActorSystem("test")
+	if _, err := sys.SpawnActor("worker", func(context.Context, interface{}) error { return nil }, 10); err != nil {
+		t.Fatalf("SpawnActor failed: %v", err)
+	}
+
+	result := sys.ShutdownOnSignal(time.Second, syscall.SIGUSR1)
+	time.Sleep(10 * time.Millisecond)
+	if err := syscall.Kill(syscall.Getpid(), syscall.SIGUSR1); err != nil {
+		t.Fatalf("Kill failed: %v", err)
+	}
+
+	select {
+	case err := <-result:
+		if err != nil {
+			t.Errorf("Shutdown failed: %v", err)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("Expected shutdown after signal")
+	}
+
+	if _, err := sys.SpawnActor("late", func(context.Context, interface{}) error { return nil }, 10); err == nil {
+		t.Error("Expected spawn to fail after shutdown")
+	}
+}
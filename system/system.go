@@ -2,6 +2,7 @@ package system
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"sync"
 	"time"
@@ -14,10 +15,30 @@ import (
 
 
 type ClusterConfig struct {
-	NodeName string
-	BindAddr string
-	BindPort int
-	Seeds    []string
+	NodeName  string
+	BindAddr  string
+	BindPort  int
+	Seeds     []string
+	Codec     string
+	Roles     []string
+	DownAfter time.Duration
+
+	SecretKeys      [][]byte
+	TLSConfig       *tls.Config
+	AdmissionSecret string
+
+	SplitBrain *SplitBrainConfig
+}
+
+
+type SplitBrainConfig struct {
+	Strategy    string
+	StableAfter time.Duration
+	Role        string
+	Referee     string
+	QuorumSize  int
+	DownIfAlone bool
+	LeaveOnly   bool
 }
 
 
@@ -36,13 +57,27 @@ type Cluster interface {
 	Leave(timeout time.Duration) error
 	Self() Node
 	Members() []Node
+	RemoteRef(address actor.Address) actor.ActorRef
+	Spawn(ctx context.Context, node string, request SpawnRequest) (actor.ActorRef, error)
+	Monitor(ctx context.Context, monitorID string, monitored actor.Address, linkType actor.MonitorType) (actor.Address, error)
+	Demonitor(monitorID string, monitored actor.Address) error
+	RegisterGlobal(ctx context.Context, name string, ref actor.ActorRef) error
+	UnregisterGlobal(name string) error
+	WhereIsGlobal(name string) (actor.ActorRef, bool)
 }
 
 
+const defaultRemoteTimeout = 5 * time.Second
+
 type ClusterProvider interface {
 	NewCluster(config *ClusterConfig, system interface{}) (Cluster, error)
 }
 
+type remoteMonitor struct {
+	monitor   string
+	monitored string
+}
+
 type ActorSystem struct {
 	name            string
 	rootSupervisor  supervisor.Supervisor
@@ -50,27 +85,40 @@ type ActorSystem struct {
 	namedRegistry   *NamedRegistry
 	actorRegistry   *Registry
 	monitorRegistry *actor.MonitorRegistry
+	remoteMonitors  map[remoteMonitor]actor.Address
 	messageBus      *messaging.MessageBus
 	cluster         Cluster
 	clusterProvider ClusterProvider
+	applications    *ApplicationController
+	factories       map[string]factory
 	mu              sync.RWMutex
 	running         bool
+	shuttingDown    bool
 }
 
 func NewActorSystem(name string) *ActorSystem {
 	strategy := supervisor.NewStrategy(supervisor.OneForOne, 10, 60)
 	rootSupervisor := supervisor.NewSupervisor("root", strategy)
 
-	return &ActorSystem{
+	s := &ActorSystem{
 		name:            name,
 		rootSupervisor:  rootSupervisor,
 		registry:        make(map[string]actor.ActorRef),
 		namedRegistry:   NewNamedRegistry(),
 		actorRegistry:   NewRegistry(),
 		monitorRegistry: actor.NewMonitorRegistry(),
+		remoteMonitors:  make(map[remoteMonitor]actor.Address),
 		messageBus:      messaging.NewMessageBus(),
+		factories:       make(map[string]factory),
 		running:         true,
 	}
+	s.applications = NewApplicationController(s)
+
+	return s
+}
+
+func (s *ActorSystem) Applications() *ApplicationController {
+	return s.applications
 }
 
 
@@ -167,6 +215,10 @@ func (s *ActorSystem) SpawnActor(id string, receiver func(context.Context, inter
 		return nil, ErrSystemStopped
 	}
 
+	if s.shuttingDown {
+		return nil, ErrSystemShuttingDown
+	}
+
 	if _, exists := s.registry[id]; exists {
 		return nil, actor.ErrInvalidActorID
 	}
@@ -200,6 +252,10 @@ func (s *ActorSystem) SpawnSupervisor(id string, strategyType supervisor.Restart
 		return nil, ErrSystemStopped
 	}
 
+	if s.shuttingDown {
+		return nil, ErrSystemShuttingDown
+	}
+
 	if _, exists := s.registry[id]; exists {
 		return nil, actor.ErrInvalidActorID
 	}
@@ -223,15 +279,25 @@ func (s *ActorSystem) SpawnSupervisor(id string, strategyType supervisor.Restart
 
 	s.registry[id] = supRef
 
-	sup, ok := supRef.(supervisor.Supervisor)
+	stableRef, ok := supRef.(*actor.StableRef)
 	if !ok {
 		return nil, fmt.Errorf("failed to cast actor to supervisor")
 	}
 
-	return sup, nil
+	if _, ok := stableRef.Actor().(supervisor.Supervisor); !ok {
+		return nil, fmt.Errorf("failed to cast actor to supervisor")
+	}
+
+	return supervisor.NewSupervisorRef(stableRef), nil
 }
 
 func (s *ActorSystem) SpawnGenServer(id string, options genserver.Options) (actor.ActorRef, error) {
+	return s.SpawnSupervisedGenServer(nil, id, options, supervisor.Permanent)
+}
+
+func (s *ActorSystem) SpawnSupervisedGenServer(sup supervisor.Supervisor, id string, options genserver.Options,
+	restartType supervisor.RestartType) (actor.ActorRef, error) {
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -239,28 +305,56 @@ func (s *ActorSystem) SpawnGenServer(id string, options genserver.Options) (acto
 		return nil, ErrSystemStopped
 	}
 
+	if s.shuttingDown {
+		return nil, ErrSystemShuttingDown
+	}
+
 	if _, exists := s.registry[id]; exists {
 		return nil, actor.ErrInvalidActorID
 	}
 
-	gs, ref, err := genserver.Start(id, options)
+	if sup == nil {
+		sup = s.rootSupervisor
+	}
+
+	spec := genserver.ChildSpec(id, options, restartType)
+	spec.OnStop = s.forgetChild(id)
+	if options.Name != "" {
+		spec.OnStart = func(ref actor.ActorRef) error {
+			return s.registerChildName(options.Name, ref)
+		}
+	}
+
+	ref, err := sup.AddChild(spec)
 	if err != nil {
 		return nil, err
 	}
 
 	s.registry[id] = ref
+	return ref, nil
+}
 
-	if options.Name != "" {
-		err = s.namedRegistry.Register(options.Name, ref)
-		if err != nil {
+func (s *ActorSystem) forgetChild(id string) func(actor.ActorRef) {
+	return func(ref actor.ActorRef) {
+		s.namedRegistry.UnregisterActor(ref.ID())
 
-			_ = gs.Stop()
+		s.mu.Lock()
+		if current, exists := s.registry[id]; exists && current == ref {
 			delete(s.registry, id)
-			return nil, err
 		}
+		s.mu.Unlock()
 	}
+}
 
-	return ref, nil
+func (s *ActorSystem) registerChildName(name string, ref actor.ActorRef) error {
+	if current, exists := s.namedRegistry.Lookup(name); exists && current.ID() == ref.ID() {
+		if current == ref {
+			return nil
+		}
+		s.namedRegistry.Unregister(name)
+	}
+
+	return s.namedRegistry.Register(name, ref)
 }
 
 func (s *ActorSystem) GetActor(id string) (actor.ActorRef, error) {
@@ -272,11 +366,28 @@ func (s *ActorSystem) GetActor(id string) (actor.ActorRef, error) {
 	}
 
 	ref, exists := s.registry[id]
-	if !exists {
+	if exists {
+		return ref, nil
+	}
+
+	address, err := actor.ParseAddress(id)
+	if err != nil || s.cluster == nil {
 		return nil, actor.ErrActorNotFound
 	}
 
-	return ref, nil
+	if address.Node != s.cluster.Self().GetName() {
+		return s.cluster.RemoteRef(address), nil
+	}
+
+	if address.IsNamed() {
+		if ref, found := s.namedRegistry.Lookup(address.Name); found {
+			return ref, nil
+		}
+	} else if ref, exists := s.registry[address.ID]; exists {
+		return ref, nil
+	}
+
+	return nil, actor.ErrActorNotFound
 }
 
 func (s *ActorSystem) RegisterName(name string, actorRef actor.ActorRef) error {
@@ -300,29 +411,105 @@ func (s *ActorSystem) WhereIs(name string) (actor.ActorRef, bool) {
 		return nil, false
 	}
 
+	if cluster, err := s.GetCluster(); err == nil {
+		if ref, found := cluster.WhereIsGlobal(name); found {
+			return ref, true
+		}
+	}
+
 	return s.namedRegistry.Lookup(name)
 }
 
+func (s *ActorSystem) RegisterGlobalName(name string, actorRef actor.ActorRef) error {
+	cluster, err := s.GetCluster()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultRemoteTimeout)
+	defer cancel()
+
+	return cluster.RegisterGlobal(ctx, name, actorRef)
+}
+
+func (s *ActorSystem) UnregisterGlobalName(name string) error {
+	cluster, err := s.GetCluster()
+	if err != nil {
+		return err
+	}
+
+	return cluster.UnregisterGlobal(name)
+}
+
 func (s *ActorSystem) Monitor(monitorID, monitoredID string, linkType actor.MonitorType) error {
 	s.mu.RLock()
-	defer s.mu.RUnlock()
-
 	if !s.running {
+		s.mu.RUnlock()
 		return ErrSystemStopped
 	}
 
-	_, exists1 := s.registry[monitorID]
-	_, exists2 := s.registry[monitoredID]
+	_, monitorLocal := s.registry[monitorID]
+	_, monitoredLocal := s.registry[monitoredID]
+	_, monitorRemote := s.remoteAddress(monitorID)
+	monitoredAddress, monitoredRemote := s.remoteAddress(monitoredID)
+	cluster := s.cluster
+	s.mu.RUnlock()
+
+	if !monitorLocal && !monitorRemote || !monitoredLocal && !monitoredRemote {
+		return actor.ErrActorNotFound
+	}
+
+	if !monitoredRemote {
+		s.monitorRegistry.Monitor(monitorID, monitoredID, linkType)
+		return nil
+	}
 
-	if !exists1 || !exists2 {
+	if !monitorLocal {
 		return actor.ErrActorNotFound
 	}
 
-	s.monitorRegistry.Monitor(monitorID, monitoredID, linkType)
+	ctx, cancel := context.WithTimeout(context.Background(), defaultRemoteTimeout)
+	defer cancel()
+
+	resolved, err := cluster.Monitor(ctx, monitorID, monitoredAddress, linkType)
+	if err != nil {
+		return fmt.Errorf("failed to monitor %s: %w", monitoredID, err)
+	}
+
+	s.mu.Lock()
+	s.remoteMonitors[remoteMonitor{monitor: monitorID, monitored: monitoredID}] = resolved
+	s.mu.Unlock()
+
+	s.monitorRegistry.Monitor(monitorID, resolved.String(), linkType)
 	return nil
 }
 
 func (s *ActorSystem) Demonitor(monitorID, monitoredID string) error {
+	s.mu.Lock()
+	if !s.running {
+		s.mu.Unlock()
+		return ErrSystemStopped
+	}
+
+	address, remote := s.remoteAddress(monitoredID)
+	key := remoteMonitor{monitor: monitorID, monitored: monitoredID}
+	if resolved, exists := s.remoteMonitors[key]; exists {
+		address = resolved
+		delete(s.remoteMonitors, key)
+	}
+	cluster := s.cluster
+	s.mu.Unlock()
+
+	if !remote {
+		s.monitorRegistry.Demonitor(monitorID, monitoredID)
+		return nil
+	}
+
+	s.monitorRegistry.Demonitor(monitorID, address.String())
+	return cluster.Demonitor(monitorID, address)
+}
+
+func (s *ActorSystem) MonitorNode(monitorID, node string) error {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
@@ -330,27 +517,95 @@ func (s *ActorSystem) Demonitor(monitorID, monitoredID string) error {
 		return ErrSystemStopped
 	}
 
-	s.monitorRegistry.Demonitor(monitorID, monitoredID)
+	if _, exists := s.registry[monitorID]; !exists {
+		return actor.ErrActorNotFound
+	}
+
+	s.monitorRegistry.MonitorNode(monitorID, node)
 	return nil
 }
 
+func (s *ActorSystem) DemonitorNode(monitorID, node string) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if !s.running {
+		return ErrSystemStopped
+	}
+
+	s.monitorRegistry.DemonitorNode(monitorID, node)
+	return nil
+}
+
+func (s *ActorSystem) NotifyNodeUp(ctx context.Context, node string) {
+	s.notifyNodeMonitors(ctx, node, true, nil)
+}
+
+func (s *ActorSystem) NotifyNodeDown(ctx context.Context, node string, reason error) {
+	for _, id := range s.monitorRegistry.WatchedActors() {
+		if address, err := actor.ParseAddress(id); err == nil && address.Node == node {
+			_ = s.NotifyFailure(ctx, id, reason)
+		}
+	}
+
+	s.notifyNodeMonitors(ctx, node, false, reason)
+}
+
+func (s *ActorSystem) notifyNodeMonitors(ctx context.Context, node string, up bool, reason error) {
+	for _, monitorID := range s.monitorRegistry.GetNodeMonitors(node) {
+		ref, err := s.GetActor(monitorID)
+		if err != nil {
+			continue
+		}
+
+		_ = ref.Send(ctx, &actor.NodeMonitorMessage{
+			Node:      node,
+			MonitorID: monitorID,
+			Up:        up,
+			Reason:    reason,
+			Timestamp: time.Now().UnixNano(),
+		})
+	}
+}
+
+func (s *ActorSystem) remoteAddress(id string) (actor.Address, bool) {
+	if s.cluster == nil {
+		return actor.Address{}, false
+	}
+
+	address, err := actor.ParseAddress(id)
+	if err != nil || address.Node == s.cluster.Self().GetName() {
+		return actor.Address{}, false
+	}
+	return address, true
+}
+
 func (s *ActorSystem) Stop() error {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+	_ = s.applications.StopAll(context.Background())
 
+	s.mu.Lock()
 	if !s.running {
+		s.mu.Unlock()
 		return nil
 	}
+	cluster := s.cluster
+	s.mu.Unlock()
 
-	if s.cluster != nil {
-		_ = s.cluster.Stop()
+	if cluster != nil {
+		_ = cluster.Stop()
 	}
 
+	s.mu.Lock()
 	s.running = false
+	s.mu.Unlock()
 	return s.rootSupervisor.Stop()
 }
 
 func (s *ActorSystem) SendMessage(ctx context.Context, actorID string, message interface{}) error {
+	if s.isShuttingDown() {
+		return ErrSystemShuttingDown
+	}
+
 	actorRef, err := s.GetActor(actorID)
 	if err != nil {
 		return err
@@ -360,9 +615,13 @@ func (s *ActorSystem) SendMessage(ctx context.Context, actorID string, message i
 }
 
 func (s *ActorSystem) SendNamedMessage(ctx context.Context, name string, message interface{}) error {
+	if s.isShuttingDown() {
+		return ErrSystemShuttingDown
+	}
+
 	actorRef, found := s.namedRegistry.Lookup(name)
 	if !found {
-		return fmt.Errorf("actor with name '%s' not found", name)
+		return fmt.Errorf("actor with name '%s' not found: %w", name, actor.ErrActorNotFound)
 	}
 
 	return actorRef.Send(ctx, message)
@@ -377,6 +636,18 @@ func (s *ActorSystem) NotifyFailure(ctx context.Context, actorID string, reason
 
 	s.namedRegistry.UnregisterActor(actorID)
 	s.monitorRegistry.CleanupActor(actorID)
+	s.forgetRemoteMonitors(actorID)
 
 	return nil
 }
+
+func (s *ActorSystem) forgetRemoteMonitors(actorID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, resolved := range s.remoteMonitors {
+		if key.monitor == actorID || resolved.String() == actorID {
+			delete(s.remoteMonitors, key)
+		}
+	}
+}
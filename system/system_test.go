@@ -0,0 +1,196 @@
+package system
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kleeedolinux/gorilix/actor"
+	"github.com/kleeedolinux/gorilix/supervisor"
+)
+
+func TestSpawnSupervisorSurvivesRestart(t *testing.T) {
+	sys := NewActorSystem("test")
+	defer sys.Stop()
+
+	sup, err := sys.SpawnSupervisor("workers", supervisor.OneForOne, 5, 60)
+	if err != nil {
+		t.Fatalf("SpawnSupervisor failed: %v", err)
+	}
+
+	ref, ok := sup.(*supervisor.SupervisorRef)
+	if !ok {
+		t.Fatalf("Expected a *supervisor.SupervisorRef, got %T", sup)
+	}
+	if ref.Incarnation() != 1 {
+		t.Fatalf("Expected incarnation 1, got %d", ref.Incarnation())
+	}
+
+	root := sys.rootSupervisor.(*supervisor.DefaultSupervisor)
+	if err := root.NotifyChildFailure(context.Background(), "workers", errors.New("crash")); err != nil {
+		t.Fatalf("NotifyChildFailure failed: %v", err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for ref.Incarnation() < 2 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if ref.Incarnation() != 2 {
+		t.Fatalf("Expected incarnation 2 after restart, got %d", ref.Incarnation())
+	}
+
+	if !sup.IsRunning() || sup.Status() != supervisor.Running {
+		t.Error("Expected the handle to report the restarted supervisor as running")
+	}
+	_, err = sup.AddChild(supervisor.ChildSpec{
+		ID: "worker",
+		CreateFunc: func() (actor.Actor, error) {
+			return actor.NewActor("worker", func(context.Context, interface{}) error { return nil }, 10), nil
+		},
+	})
+	if err != nil {
+		t.Errorf("Expected to add a child through the handle after restart, got %v", err)
+	}
+	if _, err := sup.GetChild("worker"); err != nil {
+		t.Errorf("Expected the child on the restarted supervisor, got %v", err)
+	}
+}
+
+type fakeNode string
+
+func (n fakeNode) GetName() string    { return string(n) }
+func (n fakeNode) GetAddress() string { return "127.0.0.1" }
+func (n fakeNode) GetPort() uint16    { return 7946 }
+func (n fakeNode) GetStatus() int     { return 0 }
+
+type fakeCluster struct {
+	Cluster
+	self string
+}
+
+func (c *fakeCluster) Self() Node {
+	return fakeNode(c.self)
+}
+
+func (c *fakeCluster) Stop() error {
+	return nil
+}
+
+func (c *fakeCluster) RemoteRef(address actor.Address) actor.ActorRef {
+	return actor.NewActorRef(actor.NewActor(address.String(), func(context.Context, interface{}) error { return nil }, 1))
+}
+
+func (c *fakeCluster) Monitor(ctx context.Context, monitorID string, monitored actor.Address,
+	linkType actor.MonitorType) (actor.Address, error) {
+	return monitored, nil
+}
+
+type namingCluster struct {
+	fakeCluster
+	demonitored chan actor.Address
+}
+
+func (c *namingCluster) Monitor(ctx context.Context, monitorID string, monitored actor.Address,
+	linkType actor.MonitorType) (actor.Address, error) {
+	return actor.Address{Node: monitored.Node, ID: "worker"}, nil
+}
+
+func (c *namingCluster) Demonitor(monitorID string, monitored actor.Address) error {
+	c.demonitored <- monitored
+	return nil
+}
+
+func TestDemonitorNameAddress(t *testing.T) {
+	sys := NewActorSystem("test")
+	defer sys.Stop()
+	cluster := &namingCluster{fakeCluster: fakeCluster{self: "n1"}, demonitored: make(chan actor.Address, 1)}
+	sys.cluster = cluster
+
+	received := make(chan interface{}, 10)
+	_, err := sys.SpawnActor("watcher", func(ctx context.Context, msg interface{}) error {
+		received <- msg
+		return nil
+	}, 10)
+	if err != nil {
+		t.Fatalf("SpawnActor failed: %v", err)
+	}
+
+	if err := sys.Monitor("watcher", "name:w@n2", actor.OneWay); err != nil {
+		t.Fatalf("Monitor failed: %v", err)
+	}
+	if err := sys.Demonitor("watcher", "name:w@n2"); err != nil {
+		t.Fatalf("Demonitor failed: %v", err)
+	}
+	if got := <-cluster.demonitored; got.String() != "worker@n2" {
+		t.Errorf("Expected the resolved address to be demonitored, got %s", got)
+	}
+
+	_ = sys.NotifyFailure(context.Background(), "worker@n2", actor.ErrActorStopped)
+	select {
+	case msg := <-received:
+		t.Errorf("Expected no DOWN after Demonitor, got %+v", msg)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestNodeDownFiresRemoteMonitors(t *testing.T) {
+	sys := NewActorSystem("test")
+	defer sys.Stop()
+	sys.cluster = &fakeCluster{self: "n1"}
+
+	received := make(chan interface{}, 10)
+	_, err := sys.SpawnActor("watcher", func(ctx context.Context, msg interface{}) error {
+		received <- msg
+		return nil
+	}, 10)
+	if err != nil {
+		t.Fatalf("SpawnActor failed: %v", err)
+	}
+
+	if err := sys.Monitor("watcher", "worker@n2", actor.OneWay); err != nil {
+		t.Fatalf("Monitor failed: %v", err)
+	}
+	if err := sys.Monitor("watcher", "worker@n3", actor.OneWay); err != nil {
+		t.Fatalf("Monitor failed: %v", err)
+	}
+	if err := sys.MonitorNode("watcher", "n2"); err != nil {
+		t.Fatalf("MonitorNode failed: %v", err)
+	}
+
+	sys.NotifyNodeUp(context.Background(), "n2")
+	sys.NotifyNodeDown(context.Background(), "n2", actor.ErrNoConnection)
+
+	var downs []*actor.MonitorMessage
+	var nodeEvents []*actor.NodeMonitorMessage
+	for len(downs)+len(nodeEvents) < 3 {
+		select {
+		case msg := <-received:
+			switch m := msg.(type) {
+			case *actor.MonitorMessage:
+				downs = append(downs, m)
+			case *actor.NodeMonitorMessage:
+				nodeEvents = append(nodeEvents, m)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Expected 3 notifications, got %d downs and %d node events", len(downs), len(nodeEvents))
+		}
+	}
+
+	if len(downs) != 1 || downs[0].MonitoredID != "worker@n2" || !errors.Is(downs[0].Reason, actor.ErrNoConnection) {
+		t.Errorf("Expected one noconnection DOWN for worker@n2, got %+v", downs)
+	}
+	if len(nodeEvents) != 2 || !nodeEvents[0].Up || nodeEvents[1].Up || nodeEvents[1].Reason != actor.ErrNoConnection {
+		t.Errorf("Expected node up then node down, got %+v", nodeEvents)
+	}
+
+	if err := sys.DemonitorNode("watcher", "n2"); err != nil {
+		t.Fatalf("DemonitorNode failed: %v", err)
+	}
+	sys.NotifyNodeUp(context.Background(), "n2")
+	select {
+	case msg := <-received:
+		t.Errorf("Expected no notification after DemonitorNode, got %+v", msg)
+	case <-time.After(50 * time.Millisecond):
+	}
+}